@@ -0,0 +1,130 @@
+package takeawayapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Coordinates is a parsed latitude and longitude
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// ParseCoordinates parses latitude and longitude as sent by the API
+func ParseCoordinates(latitude string, longitude string) (Coordinates, error) {
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latitude), 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("invalid latitude %q: %w", latitude, err)
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(longitude), 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("invalid longitude %q: %w", longitude, err)
+	}
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return Coordinates{}, fmt.Errorf("coordinates out of range: %v, %v", lat, lng)
+	}
+	return Coordinates{Latitude: lat, Longitude: lng}, nil
+}
+
+// Strings formats the coordinates as latitude and longitude parameters for the API
+func (c Coordinates) Strings() (latitude string, longitude string) {
+	return strconv.FormatFloat(c.Latitude, 'f', -1, 64), strconv.FormatFloat(c.Longitude, 'f', -1, 64)
+}
+
+// Coordinates returns the parsed coordinates of the address
+func (a Address) Coordinates() (Coordinates, error) {
+	return ParseCoordinates(a.Latitude, a.Longitude)
+}
+
+// SetCoordinates sets the latitude and longitude of the address
+func (a *Address) SetCoordinates(coordinates Coordinates) {
+	a.Latitude, a.Longitude = coordinates.Strings()
+}
+
+type addressesResponse struct {
+	AddressBook struct {
+		Addresses []SavedAddress `json:"ad"`
+	} `json:"ab"`
+}
+
+type addressResponse struct {
+	Address SavedAddress `json:"sa"`
+}
+
+// SavedAddress is a delivery address in the address book of the logged in user
+type SavedAddress struct {
+	ID      string  `json:"id"`
+	Label   string  `json:"lb"`
+	Address Address `json:"ad"`
+}
+
+func (sa SavedAddress) params() []interface{} {
+	return []interface{}{sa.Label, sa.Address.Street, sa.Address.Housenumber, sa.Address.Postcode, sa.Address.City, sa.Address.Latitude, sa.Address.Longitude}
+}
+
+// GetAddresses returns the saved delivery addresses of the logged in user
+func (tac *TakeAwayClient) GetAddresses() ([]SavedAddress, error) {
+	function := "getaddresses"
+	var addressesResponse addressesResponse
+	err := tac.sendRequest(function, &addressesResponse)
+	if err != nil {
+		return []SavedAddress{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return addressesResponse.AddressBook.Addresses, nil
+}
+
+// AddAddress saves a new delivery address and returns it with its ID
+func (tac *TakeAwayClient) AddAddress(address SavedAddress) (SavedAddress, error) {
+	function := "addaddress"
+	var addressResponse addressResponse
+	err := tac.sendRequest(function, &addressResponse, address.params()...)
+	if err != nil {
+		return SavedAddress{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return addressResponse.Address, nil
+}
+
+// UpdateAddress changes the saved delivery address with address.ID
+func (tac *TakeAwayClient) UpdateAddress(address SavedAddress) (SavedAddress, error) {
+	function := "updateaddress"
+	if address.ID == "" {
+		return SavedAddress{}, fmt.Errorf("missing address ID")
+	}
+	var addressResponse addressResponse
+	err := tac.sendRequest(function, &addressResponse, append([]interface{}{address.ID}, address.params()...)...)
+	if err != nil {
+		return SavedAddress{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return addressResponse.Address, nil
+}
+
+// DeleteAddress removes the saved delivery address
+func (tac *TakeAwayClient) DeleteAddress(addressID string) error {
+	function := "deleteaddress"
+	var deleteResponse struct{}
+	err := tac.sendRequest(function, &deleteResponse, addressID)
+	if err != nil {
+		return fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return nil
+}
+
+// DeliversTo reports whether the restaurant delivers to the postcode according to its DeliveryData.
+// Like Basket.Validate it assumes a restaurant without delivery areas delivers everywhere.
+func (rd RestaurantData) DeliversTo(postcode string) bool {
+	_, _, err := deliveryArea(rd, strings.TrimSpace(postcode))
+	return err == nil
+}
+
+// DeliverableAddresses returns the saved addresses the restaurant delivers to
+func DeliverableAddresses(restaurant RestaurantData, addresses []SavedAddress) []SavedAddress {
+	var deliverable []SavedAddress
+	for _, address := range addresses {
+		if restaurant.DeliversTo(address.Address.Postcode) {
+			deliverable = append(deliverable, address)
+		}
+	}
+	return deliverable
+}
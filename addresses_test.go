@@ -0,0 +1,57 @@
+package takeawayapi
+
+import "testing"
+
+func TestAddresses(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{
+		"getaddresses":  `{"ab":{"ad":[{"id":"1","lb":"Office","ad":{"st":"Hauptstraße","hn":"1","pc":"90461","tn":"Nürnberg","lt":"49.43","ln":"11.08"}},{"id":"2","lb":"Home","ad":{"pc":"10115"}}]}}`,
+		"addaddress":    `{"sa":{"id":"3","lb":"New","ad":{"pc":"90459"}}}`,
+		"deleteaddress": `{}`,
+	})
+	addresses, err := tac.GetAddresses()
+	if err != nil {
+		t.Fatalf(`GetAddresses errored with error: %v`, err)
+	}
+	if len(addresses) != 2 || addresses[0].Label != "Office" {
+		t.Fatalf(`GetAddresses returned wrong addresses: %v`, addresses)
+	}
+	coordinates, err := addresses[0].Address.Coordinates()
+	if err != nil || coordinates.Latitude != 49.43 || coordinates.Longitude != 11.08 {
+		t.Fatalf(`Coordinates returned %v, %v`, coordinates, err)
+	}
+
+	newAddress := SavedAddress{Label: "New", Address: Address{Postcode: "90459"}}
+	newAddress.Address.SetCoordinates(Coordinates{Latitude: 49.44, Longitude: 11.07})
+	added, err := tac.AddAddress(newAddress)
+	if err != nil || added.ID != "3" {
+		t.Fatalf(`AddAddress returned %v, %v`, added, err)
+	}
+	if form := ts.LastForm("addaddress"); form.Get("var2") != "New" || form.Get("var7") != "49.44" {
+		t.Fatalf(`AddAddress sent wrong parameters: %v`, form)
+	}
+	if _, err := tac.UpdateAddress(SavedAddress{}); err == nil {
+		t.Fatalf(`UpdateAddress accepted an address without ID`)
+	}
+	if err := tac.DeleteAddress("1"); err != nil {
+		t.Fatalf(`DeleteAddress errored with error: %v`, err)
+	}
+
+	deliverable := DeliverableAddresses(testRestaurantData(t), addresses)
+	if len(deliverable) != 1 || deliverable[0].ID != "1" {
+		t.Fatalf(`DeliverableAddresses returned wrong addresses: %v`, deliverable)
+	}
+	// Without delivery areas the restaurant delivers everywhere, like for Basket.Validate
+	everywhere := testRestaurantData(t)
+	everywhere.DeliveryData.Da = nil
+	if deliverable := DeliverableAddresses(everywhere, addresses); len(deliverable) != len(addresses) {
+		t.Fatalf(`DeliverableAddresses returned wrong addresses without delivery areas: %v`, deliverable)
+	}
+}
+
+func TestParseCoordinates(t *testing.T) {
+	for _, invalid := range [][2]string{{"", "1"}, {"91", "0"}, {"0", "abc"}} {
+		if _, err := ParseCoordinates(invalid[0], invalid[1]); err == nil {
+			t.Fatalf(`ParseCoordinates accepted %v`, invalid)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package takeawayapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// sessionTokenParam is the request parameter carrying the session token
+const sessionTokenParam = "sessionToken"
+
+// ErrNoSession is returned if there is no session, e.g. by a TokenStore which has nothing stored
+var ErrNoSession = errors.New("no session")
+
+// sessionlessFunctions never get the session attached
+var sessionlessFunctions = map[string]bool{
+	"login": true,
+}
+
+// Session is an authenticated user session
+type Session struct {
+	Token     string    `json:"token"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Expired reports whether the session is known to be expired
+func (s Session) Expired() bool {
+	return !s.ExpiresAt.IsZero() && time.Now().After(s.ExpiresAt)
+}
+
+// CredentialsProvider supplies the username and password used to log in
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (username string, password string, err error)
+}
+
+// StaticCredentials is a CredentialsProvider returning fixed credentials
+type StaticCredentials struct {
+	Username string
+	Password string
+}
+
+// Credentials returns the fixed username and password
+func (c StaticCredentials) Credentials(ctx context.Context) (string, string, error) {
+	return c.Username, c.Password, nil
+}
+
+// TokenStore persists the session so it survives restarts.
+// Load returns ErrNoSession if nothing is stored.
+type TokenStore interface {
+	Load() (Session, error)
+	Save(session Session) error
+	Clear() error
+}
+
+// AuthConfig configures the authentication of a client
+type AuthConfig struct {
+	// Credentials are used to log in and to log in again when the session expired
+	Credentials CredentialsProvider
+	// TokenStore persists the session, defaults to a MemoryTokenStore
+	TokenStore TokenStore
+	// SessionExpiredErrorIDs are the API error IDs after which the client logs in again and retries the request.
+	// The API doesn't document its error IDs, so there is no default: without IDs the client only logs in again
+	// when a restored session is past its ExpiresAt.
+	SessionExpiredErrorIDs []int
+}
+
+type authState struct {
+	config AuthConfig
+
+	mu      sync.Mutex
+	session Session
+	// loginMu makes concurrent requests with an expired session log in only once
+	loginMu sync.Mutex
+}
+
+// EnableAuth configures authentication and restores a stored session if there is one.
+// Once logged in the session is attached to all requests.
+func (tac *TakeAwayClient) EnableAuth(config AuthConfig) error {
+	if config.TokenStore == nil {
+		config.TokenStore = NewMemoryTokenStore()
+	}
+	auth := &authState{config: config}
+	session, err := config.TokenStore.Load()
+	if err != nil && !errors.Is(err, ErrNoSession) {
+		return fmt.Errorf("error loading session: %w", err)
+	}
+	if err == nil && !session.Expired() {
+		auth.session = session
+	}
+	tac.auth = auth
+	return nil
+}
+
+// Session returns the current session
+func (tac *TakeAwayClient) Session() (Session, bool) {
+	if tac.auth == nil {
+		return Session{}, false
+	}
+	tac.auth.mu.Lock()
+	defer tac.auth.mu.Unlock()
+	return tac.auth.session, tac.auth.session.Token != ""
+}
+
+type loginResponse struct {
+	Login struct {
+		Token        string `json:"tk"`
+		ExpiresAtStr string `json:"ex"`
+	} `json:"ls"`
+}
+
+// Login logs in with the configured credentials and stores the new session
+func (tac *TakeAwayClient) Login() (Session, error) {
+	function := "login"
+	if tac.auth == nil || tac.auth.config.Credentials == nil {
+		return Session{}, errors.New("no credentials configured, use EnableAuth")
+	}
+	username, password, err := tac.auth.config.Credentials.Credentials(tac.context())
+	if err != nil {
+		return Session{}, fmt.Errorf("error getting credentials: %w", err)
+	}
+	var loginResponse loginResponse
+	err = tac.sendRequest(function, &loginResponse, username, password)
+	if err != nil {
+		return Session{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	if loginResponse.Login.Token == "" {
+		return Session{}, errors.New("login returned no session token")
+	}
+	session := Session{Token: loginResponse.Login.Token, Username: username}
+	if loginResponse.Login.ExpiresAtStr != "" {
+		session.ExpiresAt, err = ParseTakeAwayTime(loginResponse.Login.ExpiresAtStr)
+		if err != nil {
+			return Session{}, fmt.Errorf("error parsing session expiry time: %w", err)
+		}
+	}
+	if err := tac.auth.config.TokenStore.Save(session); err != nil {
+		return Session{}, fmt.Errorf("error saving session: %w", err)
+	}
+	tac.auth.mu.Lock()
+	tac.auth.session = session
+	tac.auth.mu.Unlock()
+	return session, nil
+}
+
+// Logout ends the current session and removes it from the token store
+func (tac *TakeAwayClient) Logout() error {
+	function := "logout"
+	session, ok := tac.Session()
+	if !ok {
+		return ErrNoSession
+	}
+	var logoutResponse struct{}
+	err := tac.sendRequest(function, &logoutResponse, session.Token)
+	// The session is dropped locally even if the API call failed
+	tac.auth.mu.Lock()
+	tac.auth.session = Session{}
+	tac.auth.mu.Unlock()
+	if clearErr := tac.auth.config.TokenStore.Clear(); clearErr != nil && err == nil {
+		return fmt.Errorf("error clearing session: %w", clearErr)
+	}
+	if err != nil {
+		return fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return nil
+}
+
+// attachSession adds the session token to the request parameters and returns the token used
+func (tac *TakeAwayClient) attachSession(function string, data url.Values) string {
+	if sessionlessFunctions[function] {
+		return ""
+	}
+	session, ok := tac.Session()
+	if !ok {
+		return ""
+	}
+	data.Set(sessionTokenParam, session.Token)
+	return session.Token
+}
+
+// sessionKey identifies the session in cache keys so responses of different users are never shared
+func (tac *TakeAwayClient) sessionKey() string {
+	session, ok := tac.Session()
+	if !ok {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(session.Token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// shouldReauthenticate reports whether err means the session expired and the client can log in again
+func (tac *TakeAwayClient) shouldReauthenticate(function string, err error) bool {
+	if tac.auth == nil || tac.auth.config.Credentials == nil || sessionlessFunctions[function] || function == "logout" {
+		return false
+	}
+	var apiError *APIError
+	return errors.As(err, &apiError) && slices.Contains(tac.auth.config.SessionExpiredErrorIDs, apiError.ID)
+}
+
+// reauthenticate logs in again unless another request already replaced the expired token
+func (tac *TakeAwayClient) reauthenticate(expiredToken string) error {
+	tac.auth.loginMu.Lock()
+	defer tac.auth.loginMu.Unlock()
+	if session, ok := tac.Session(); ok && session.Token != expiredToken {
+		return nil
+	}
+	_, err := tac.Login()
+	return err
+}
+
+// MemoryTokenStore keeps the session in memory only
+type MemoryTokenStore struct {
+	mu      sync.Mutex
+	session *Session
+}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+// Load returns the stored session
+func (s *MemoryTokenStore) Load() (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.session == nil {
+		return Session{}, ErrNoSession
+	}
+	return *s.session, nil
+}
+
+// Save stores the session
+func (s *MemoryTokenStore) Save(session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = &session
+	return nil
+}
+
+// Clear removes the stored session
+func (s *MemoryTokenStore) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = nil
+	return nil
+}
+
+// FileTokenStore keeps the session in a JSON file readable only by the current user
+type FileTokenStore struct {
+	Path string
+}
+
+// NewFileTokenStore creates a FileTokenStore writing to path
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{Path: path}
+}
+
+// Load reads the session from the file
+func (s *FileTokenStore) Load() (Session, error) {
+	content, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Session{}, ErrNoSession
+	}
+	if err != nil {
+		return Session{}, err
+	}
+	var session Session
+	if err := json.Unmarshal(content, &session); err != nil {
+		return Session{}, fmt.Errorf("error decoding session file: %w", err)
+	}
+	return session, nil
+}
+
+// Save writes the session to the file
+func (s *FileTokenStore) Save(session Session) error {
+	content, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path, content, 0o600)
+}
+
+// Clear removes the file
+func (s *FileTokenStore) Clear() error {
+	err := os.Remove(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
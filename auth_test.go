@@ -0,0 +1,101 @@
+package takeawayapi
+
+import (
+	"errors"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLoginAndSessionAttachment(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{
+		"login":            `{"ls":{"tk":"token1","ex":"2099-01-01 00:00:00"}}`,
+		"getcountriesdata": testCountriesResponse,
+		"logout":           `{}`,
+	})
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "session.json"))
+	if err := tac.EnableAuth(AuthConfig{Credentials: StaticCredentials{Username: "max", Password: "secret"}, TokenStore: store}); err != nil {
+		t.Fatalf(`EnableAuth errored with error: %v`, err)
+	}
+	session, err := tac.Login()
+	if err != nil {
+		t.Fatalf(`Login errored with error: %v`, err)
+	}
+	if session.Token != "token1" || session.Username != "max" || session.ExpiresAt.IsZero() {
+		t.Fatalf(`Login returned wrong session: %+v`, session)
+	}
+	if form := ts.LastForm("login"); form.Get("var2") != "max" || form.Get(sessionTokenParam) != "" {
+		t.Fatalf(`Login sent wrong parameters: %v`, form)
+	}
+
+	if _, err := tac.GetCountriesData(); err != nil {
+		t.Fatalf(`GetCountriesData errored with error: %v`, err)
+	}
+	if ts.LastForm("getcountriesdata").Get(sessionTokenParam) != "token1" {
+		t.Fatalf(`Session was not attached to request`)
+	}
+
+	// A new client restores the stored session
+	restored, _ := newTestClient(t, nil)
+	if err := restored.EnableAuth(AuthConfig{TokenStore: store}); err != nil {
+		t.Fatalf(`EnableAuth errored with error: %v`, err)
+	}
+	if session, ok := restored.Session(); !ok || session.Token != "token1" {
+		t.Fatalf(`Session was not restored: %+v`, session)
+	}
+
+	if err := tac.Logout(); err != nil {
+		t.Fatalf(`Logout errored with error: %v`, err)
+	}
+	if _, err := store.Load(); !errors.Is(err, ErrNoSession) {
+		t.Fatalf(`Logout did not clear the token store: %v`, err)
+	}
+	if _, ok := tac.Session(); ok {
+		t.Fatalf(`Logout did not drop the session`)
+	}
+}
+
+func TestReauthenticateOnExpiredSession(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{})
+	var logins atomic.Int32
+	ts.SetHandler("login", func(form url.Values) string {
+		if logins.Add(1) == 1 {
+			return `{"ls":{"tk":"old"}}`
+		}
+		return `{"ls":{"tk":"new"}}`
+	})
+	ts.SetHandler("getcountriesdata", func(form url.Values) string {
+		if form.Get(sessionTokenParam) != "new" {
+			return `{"nok":{"error":{"errorid":401,"errortext":"session expired"}}}`
+		}
+		return testCountriesResponse
+	})
+	config := AuthConfig{Credentials: StaticCredentials{Username: "max", Password: "secret"}, SessionExpiredErrorIDs: []int{401}}
+	if err := tac.EnableAuth(config); err != nil {
+		t.Fatalf(`EnableAuth errored with error: %v`, err)
+	}
+	if _, err := tac.Login(); err != nil {
+		t.Fatalf(`Login errored with error: %v`, err)
+	}
+
+	countries, err := tac.GetCountriesData()
+	if err != nil {
+		t.Fatalf(`GetCountriesData errored with error: %v`, err)
+	}
+	if len(countries.CountryData) != 1 || logins.Load() != 2 {
+		t.Fatalf(`Expected one re-login and a successful retry, got %v logins`, logins.Load())
+	}
+
+	ts.SetHandler("getcountriesdata", func(form url.Values) string {
+		return `{"nok":{"error":{"errorid":7,"errortext":"other"}}}`
+	})
+	_, err = tac.GetCountriesData()
+	var apiError *APIError
+	if !errors.As(err, &apiError) || apiError.ID != 7 {
+		t.Fatalf(`Expected APIError 7, got %v`, err)
+	}
+	if logins.Load() != 2 {
+		t.Fatalf(`Other API errors must not trigger a re-login`)
+	}
+}
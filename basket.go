@@ -0,0 +1,229 @@
+package takeawayapi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// OrderMode is the way an order reaches the customer, as used by the OrderingMode parameter
+type OrderMode int
+
+const (
+	OrderModeDelivery OrderMode = 1
+	OrderModePickup   OrderMode = 2
+)
+
+// BasketItem is a product in a basket
+type BasketItem struct {
+	ProductID string
+	Quantity  int
+	// SideDishIDs are the IDs of the chosen side dish options (SideDish.Cc.Ch)
+	SideDishIDs []string
+	Remark      string
+}
+
+// Basket is a list of products to be ordered from a single restaurant
+type Basket struct {
+	RestaurantID string
+	Mode         OrderMode
+	// Postcode is the delivery postcode, it determines the minimum order and delivery costs
+	Postcode string
+	Items    []BasketItem
+	// Voucher is applied to the subtotal, see TakeAwayClient.ValidateVoucher
+	Voucher *Voucher
+}
+
+// BasketTotals are the amounts of a basket
+type BasketTotals struct {
+	Subtotal      Money
+	DeliveryCosts Money
+	Discount      Money
+	Total         Money
+}
+
+// ValidatedBasket is a basket which was checked against the menu of its restaurant.
+// It can only be created by Basket.Validate.
+type ValidatedBasket struct {
+	Basket Basket
+	Totals BasketTotals
+
+	validated bool
+	// paymentMethods are the payment methods offered by the restaurant
+	paymentMethods []PaymentMethod
+}
+
+// Add adds quantity of the product to the basket
+func (b *Basket) Add(productID string, quantity int, sideDishIDs ...string) {
+	b.Items = append(b.Items, BasketItem{ProductID: productID, Quantity: quantity, SideDishIDs: sideDishIDs})
+}
+
+// Products returns all products on the menu of the restaurant
+func (rd RestaurantData) Products() []Product {
+	var products []Product
+	for _, category := range rd.Menu.CategorieStruct.Categories {
+		products = append(products, category.ProductStruct.Products...)
+	}
+	return products
+}
+
+// FindProduct returns the product with the given ID from the menu of the restaurant
+func (rd RestaurantData) FindProduct(productID string) (Product, bool) {
+	for _, product := range rd.Products() {
+		if product.ID == productID {
+			return product, true
+		}
+	}
+	return Product{}, false
+}
+
+// Price returns the price of the product for the given order mode
+func (p Product) Price(mode OrderMode) (Money, error) {
+	if mode == OrderModePickup {
+		return ParseMoney(p.PickupCost)
+	}
+	return ParseMoney(p.DeliveryCost)
+}
+
+// itemPrice returns the price of a single unit of item including its side dishes
+func itemPrice(product Product, item BasketItem, mode OrderMode) (Money, error) {
+	price, err := product.Price(mode)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing price of product %s: %w", product.ID, err)
+	}
+	for _, sideDishID := range item.SideDishIDs {
+		found := false
+		for _, sideDish := range product.SideItems.SideDishes {
+			for _, choice := range sideDish.Cc.Ch {
+				if choice.ID != sideDishID {
+					continue
+				}
+				cost := choice.DeliveryCost
+				if mode == OrderModePickup {
+					cost = choice.PickupCost
+				}
+				choicePrice, err := ParseMoney(cost)
+				if err != nil {
+					return 0, fmt.Errorf("error parsing price of side dish %s: %w", sideDishID, err)
+				}
+				price += choicePrice
+				found = true
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("side dish %s is not available for product %s", sideDishID, product.ID)
+		}
+	}
+	return price, nil
+}
+
+// deliveryArea returns the minimum order and costs of the delivery area containing postcode
+func deliveryArea(restaurant RestaurantData, postcode string) (minimum string, costs []DeliveryCost, err error) {
+	if len(restaurant.DeliveryData.Da) == 0 {
+		return restaurant.Dc.Ma, nil, nil
+	}
+	for _, area := range restaurant.DeliveryData.Da {
+		if slices.Contains(area.Postcodes.PostCodesArray, postcode) {
+			return area.Ma, area.Costs, nil
+		}
+	}
+	return "", nil, fmt.Errorf("restaurant %s does not deliver to postcode %s", restaurant.RestaurantID, postcode)
+}
+
+// deliveryCosts returns the costs of the tier matching subtotal. A tier without upper bound is open ended.
+// If no tier matches the costs are unknown and an error is returned.
+func deliveryCosts(costs []DeliveryCost, subtotal Money) (Money, error) {
+	for _, tier := range costs {
+		from, err := ParseMoney(tier.Fr)
+		if err != nil {
+			return 0, err
+		}
+		to, err := ParseMoney(tier.To)
+		if err != nil {
+			return 0, err
+		}
+		if subtotal >= from && (to == 0 || subtotal < to) {
+			return ParseMoney(tier.Ct)
+		}
+	}
+	return 0, fmt.Errorf("no delivery cost tier for subtotal %v", subtotal)
+}
+
+// Totals calculates the amounts of the basket from the menu of the restaurant
+func (b Basket) Totals(restaurant RestaurantData) (BasketTotals, error) {
+	var totals BasketTotals
+	for _, item := range b.Items {
+		product, ok := restaurant.FindProduct(item.ProductID)
+		if !ok {
+			return BasketTotals{}, fmt.Errorf("product %s is not on the menu", item.ProductID)
+		}
+		price, err := itemPrice(product, item, b.Mode)
+		if err != nil {
+			return BasketTotals{}, err
+		}
+		totals.Subtotal += price * Money(item.Quantity)
+	}
+	if b.Mode == OrderModeDelivery {
+		_, costs, err := deliveryArea(restaurant, b.Postcode)
+		if err != nil {
+			return BasketTotals{}, err
+		}
+		totals.DeliveryCosts, err = deliveryCosts(costs, totals.Subtotal)
+		if err != nil {
+			return BasketTotals{}, fmt.Errorf("error calculating delivery costs: %w", err)
+		}
+	}
+	if b.Voucher != nil {
+		discount, err := b.Voucher.Discount(b.RestaurantID, totals.Subtotal)
+		if err != nil {
+			return BasketTotals{}, err
+		}
+		totals.Discount = discount
+	}
+	totals.Total = totals.Subtotal + totals.DeliveryCosts - totals.Discount
+	return totals, nil
+}
+
+// Validate checks the basket against the menu of the restaurant and calculates its totals
+func (b Basket) Validate(restaurant RestaurantData) (ValidatedBasket, error) {
+	if b.RestaurantID != restaurant.RestaurantID {
+		return ValidatedBasket{}, fmt.Errorf("basket is for restaurant %s, not %s", b.RestaurantID, restaurant.RestaurantID)
+	}
+	if b.Mode != OrderModeDelivery && b.Mode != OrderModePickup {
+		return ValidatedBasket{}, fmt.Errorf("invalid order mode: %d", b.Mode)
+	}
+	if len(b.Items) == 0 {
+		return ValidatedBasket{}, errors.New("basket is empty")
+	}
+	for _, item := range b.Items {
+		if item.Quantity <= 0 {
+			return ValidatedBasket{}, fmt.Errorf("invalid quantity %d for product %s", item.Quantity, item.ProductID)
+		}
+	}
+	totals, err := b.Totals(restaurant)
+	if err != nil {
+		return ValidatedBasket{}, err
+	}
+	if b.Mode == OrderModeDelivery {
+		minimum, _, err := deliveryArea(restaurant, b.Postcode)
+		if err != nil {
+			return ValidatedBasket{}, err
+		}
+		minimumOrder, err := ParseMoney(minimum)
+		if err != nil {
+			return ValidatedBasket{}, fmt.Errorf("error parsing minimum order: %w", err)
+		}
+		if totals.Subtotal < minimumOrder {
+			return ValidatedBasket{}, fmt.Errorf("subtotal %v is below the minimum order of %v", totals.Subtotal, minimumOrder)
+		}
+	}
+	options, err := restaurant.PaymentOptions()
+	if err != nil {
+		return ValidatedBasket{}, fmt.Errorf("error parsing payment methods: %w", err)
+	}
+	methods := make([]PaymentMethod, 0, len(options))
+	for _, option := range options {
+		methods = append(methods, option.Method)
+	}
+	return ValidatedBasket{Basket: b, Totals: totals, validated: true, paymentMethods: methods}, nil
+}
@@ -0,0 +1,113 @@
+package takeawayapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultFetchWorkers is the number of concurrent requests used by FetchAllRestaurantData if FetchOptions.Workers is not set
+const DefaultFetchWorkers = 4
+
+// FetchOptions configures FetchAllRestaurantData
+type FetchOptions struct {
+	// Workers is the number of concurrent requests, defaults to DefaultFetchWorkers
+	Workers int
+	// Checkout fetches GetRestaurantCheckoutData (without menu) instead of GetRestaurantData
+	Checkout bool
+
+	Postcode    string
+	CountryCode CountryCode
+	Latitude    string
+	Longitude   string
+	ClientID    string
+}
+
+// RestaurantDataResult is the outcome of fetching the data of a single restaurant
+type RestaurantDataResult struct {
+	Restaurant     Restaurant
+	RestaurantData RestaurantData
+	Err            error
+}
+
+// FetchAllRestaurantData fetches the data of all restaurants with a bounded number of concurrent requests.
+// Results are sent on the returned channel as they complete, one per restaurant, and the channel is closed afterwards.
+// A failing restaurant does not abort the batch, its error is reported in its result.
+// The client's RateLimiter applies to every request.
+func (tac *TakeAwayClient) FetchAllRestaurantData(ctx context.Context, restaurants []Restaurant, opts FetchOptions) <-chan RestaurantDataResult {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultFetchWorkers
+	}
+	client := tac.WithContext(ctx)
+
+	// Buffered for all results so workers never block on a slow or absent reader
+	results := make(chan RestaurantDataResult, len(restaurants))
+	jobs := make(chan Restaurant)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for restaurant := range jobs {
+				result := RestaurantDataResult{Restaurant: restaurant}
+				if err := ctx.Err(); err != nil {
+					result.Err = err
+				} else if opts.Checkout {
+					result.RestaurantData, result.Err = client.GetRestaurantCheckoutData(restaurant.ID, opts.Postcode, opts.CountryCode, opts.Latitude, opts.Longitude, opts.ClientID)
+				} else {
+					result.RestaurantData, result.Err = client.GetRestaurantData(restaurant.ID, opts.Postcode, opts.CountryCode, opts.Latitude, opts.Longitude, opts.ClientID)
+				}
+				results <- result
+			}
+		}()
+	}
+
+	go func() {
+		for _, restaurant := range restaurants {
+			jobs <- restaurant
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// BatchError collects the errors of a batch, keyed by restaurant ID
+type BatchError struct {
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	messages := make([]string, 0, len(ids))
+	for _, id := range ids {
+		messages = append(messages, fmt.Sprintf("%s: %v", id, e.Errors[id]))
+	}
+	return fmt.Sprintf("%d restaurants failed: %s", len(ids), strings.Join(messages, "; "))
+}
+
+// CollectRestaurantData reads all results and returns the fetched data keyed by restaurant ID.
+// If any restaurant failed the error is a *BatchError, the data of all other restaurants is still returned.
+func CollectRestaurantData(results <-chan RestaurantDataResult) (map[string]RestaurantData, error) {
+	restaurantData := map[string]RestaurantData{}
+	batchError := &BatchError{Errors: map[string]error{}}
+	for result := range results {
+		if result.Err != nil {
+			batchError.Errors[result.Restaurant.ID] = result.Err
+			continue
+		}
+		restaurantData[result.Restaurant.ID] = result.RestaurantData
+	}
+	if len(batchError.Errors) > 0 {
+		return restaurantData, batchError
+	}
+	return restaurantData, nil
+}
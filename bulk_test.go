@@ -0,0 +1,76 @@
+package takeawayapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testRestaurantDataResponse = `{"rd":{"nm":"Pizza Test","ri":"O3QQ11PN","ct":"2024-01-01 12:00:00","dt":{"td":{"ti":[]},"tm":{"ti":[]}}}}`
+
+func TestFetchAllRestaurantData(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{"getrestaurantdata": testRestaurantDataResponse})
+	restaurants := []Restaurant{{ID: "A"}, {ID: "B"}, {ID: "C"}}
+
+	results := tac.FetchAllRestaurantData(context.Background(), restaurants, FetchOptions{Workers: 2, CountryCode: DE})
+	restaurantData, err := CollectRestaurantData(results)
+	if err != nil {
+		t.Fatalf(`FetchAllRestaurantData errored with error: %v`, err)
+	}
+	if len(restaurantData) != 3 || restaurantData["B"].Name != "Pizza Test" {
+		t.Fatalf(`FetchAllRestaurantData returned wrong data: %v`, restaurantData)
+	}
+	if ts.Hits("getrestaurantdata") != 3 {
+		t.Fatalf(`Expected 3 upstream requests, got %v`, ts.Hits("getrestaurantdata"))
+	}
+}
+
+func TestFetchAllRestaurantDataErrors(t *testing.T) {
+	tac, _ := newTestClient(t, map[string]string{})
+	restaurants := []Restaurant{{ID: "A"}, {ID: "B"}}
+
+	restaurantData, err := CollectRestaurantData(tac.FetchAllRestaurantData(context.Background(), restaurants, FetchOptions{Checkout: true}))
+	var batchError *BatchError
+	if !errors.As(err, &batchError) {
+		t.Fatalf(`Expected BatchError, got %v`, err)
+	}
+	if len(batchError.Errors) != 2 || len(restaurantData) != 0 {
+		t.Fatalf(`Expected 2 failed restaurants, got %v`, batchError.Errors)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(50, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf(`Wait errored with error: %v`, err)
+		}
+	}
+	// The burst covers two requests, the other two need to wait 20ms each
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf(`RateLimiter did not limit, elapsed %v`, elapsed)
+	}
+
+	slow := NewRateLimiter(0.001, 1)
+	if err := slow.Wait(context.Background()); err != nil {
+		t.Fatalf(`First token should be available from the burst: %v`, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := slow.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf(`Expected context canceled, got %v`, err)
+	}
+
+	for _, rate := range []float64{0, -1} {
+		unlimited := NewRateLimiter(rate, 1)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		for i := 0; i < 100; i++ {
+			if err := unlimited.Wait(ctx); err != nil {
+				t.Fatalf(`Expected rate %v not to limit, got %v`, rate, err)
+			}
+		}
+		cancel()
+	}
+}
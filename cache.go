@@ -0,0 +1,302 @@
+package takeawayapi
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of responses kept by the in-memory cache if no Cache is configured
+const DefaultCacheSize = 256
+
+// DefaultCacheTTLs are used by EnableCache if CacheConfig.TTLs is nil
+var DefaultCacheTTLs = map[string]time.Duration{
+	"getcountriesdata":          24 * time.Hour,
+	"getrestaurantdata":         time.Hour,
+	"getrestaurantcheckoutdata": time.Hour,
+	"restaurantreviews":         time.Hour,
+}
+
+// CacheEntry is a raw API response stored in a Cache
+type CacheEntry struct {
+	Body     []byte    `json:"body"`
+	StoredAt time.Time `json:"storedAt"`
+}
+
+// Cache stores raw API responses. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+	Delete(key string)
+}
+
+// CacheConfig configures the response cache of a client
+type CacheConfig struct {
+	// Cache stores the responses, defaults to an LRUCache with DefaultCacheSize entries
+	Cache Cache
+	// TTLs maps API function names (e.g. "getrestaurantdata") to the time a response stays fresh.
+	// Functions without a TTL are never cached. Defaults to DefaultCacheTTLs.
+	TTLs map[string]time.Duration
+	// StaleWhileRevalidate is the time after the TTL expired in which the stale response
+	// is still returned while it is refreshed in the background
+	StaleWhileRevalidate time.Duration
+}
+
+// CacheMode controls how a single call uses the cache
+type CacheMode int
+
+const (
+	// CacheDefault returns fresh cached responses and stores new ones
+	CacheDefault CacheMode = iota
+	// CacheRefresh ignores cached responses but stores the new one
+	CacheRefresh
+	// CacheBypass neither reads nor writes the cache
+	CacheBypass
+)
+
+type responseCache struct {
+	config CacheConfig
+
+	mu           sync.Mutex
+	revalidating map[string]bool
+}
+
+// EnableCache puts a response cache in front of all requests of the client
+func (tac *TakeAwayClient) EnableCache(config CacheConfig) {
+	if config.Cache == nil {
+		config.Cache = NewLRUCache(DefaultCacheSize)
+	}
+	if config.TTLs == nil {
+		config.TTLs = DefaultCacheTTLs
+	}
+	tac.cache = &responseCache{config: config, revalidating: map[string]bool{}}
+}
+
+// DisableCache removes the response cache from the client
+func (tac *TakeAwayClient) DisableCache() {
+	tac.cache = nil
+}
+
+// WithCacheMode returns a copy of the client which uses the cache according to mode.
+// The copy shares the cache and settings with the original client.
+//
+//	data, err := tac.WithCacheMode(takeawayapi.CacheBypass).GetRestaurantData(...)
+func (tac *TakeAwayClient) WithCacheMode(mode CacheMode) *TakeAwayClient {
+	client := *tac
+	client.cacheMode = mode
+	return &client
+}
+
+func (tac *TakeAwayClient) cacheKey(function string, md5sum string) string {
+	return tac.BaseURL + "|" + tac.Language + "|" + function + "|" + md5sum + "|" + tac.sessionKey()
+}
+
+// fetch returns the response body for the signed request, using the cache if enabled
+func (tac *TakeAwayClient) fetch(function string, md5sum string, data url.Values) ([]byte, error) {
+	key := tac.cacheKey(function, md5sum)
+	rc := tac.cache
+	if rc == nil || tac.cacheMode == CacheBypass {
+		return tac.upstream(tac.context(), function, key, data)
+	}
+	ttl, ok := rc.config.TTLs[function]
+	if !ok || ttl <= 0 {
+		return tac.upstream(tac.context(), function, key, data)
+	}
+
+	if tac.cacheMode == CacheDefault {
+		if entry, ok := rc.config.Cache.Get(key); ok {
+			age := time.Since(entry.StoredAt)
+			if age < ttl {
+				return entry.Body, nil
+			}
+			if age < ttl+rc.config.StaleWhileRevalidate {
+				// The caller may cancel its context as soon as it has the stale response
+				ctx := context.WithoutCancel(tac.context())
+				rc.revalidate(key, func() ([]byte, error) { return tac.upstream(ctx, function, key, data) })
+				return entry.Body, nil
+			}
+		}
+	}
+
+	body, err := tac.upstream(tac.context(), function, key, data)
+	if err != nil {
+		return nil, err
+	}
+	rc.store(key, body)
+	return body, nil
+}
+
+// store saves body in the cache unless it contains an API error
+func (rc *responseCache) store(key string, body []byte) {
+	if checkAPIError(body) != nil {
+		return
+	}
+	rc.config.Cache.Set(key, CacheEntry{Body: body, StoredAt: time.Now()})
+}
+
+// revalidate refreshes key in the background, at most once at a time
+func (rc *responseCache) revalidate(key string, request func() ([]byte, error)) {
+	rc.mu.Lock()
+	if rc.revalidating[key] {
+		rc.mu.Unlock()
+		return
+	}
+	rc.revalidating[key] = true
+	rc.mu.Unlock()
+
+	go func() {
+		defer func() {
+			rc.mu.Lock()
+			delete(rc.revalidating, key)
+			rc.mu.Unlock()
+		}()
+		body, err := request()
+		if err != nil {
+			return
+		}
+		rc.store(key, body)
+	}()
+}
+
+// LRUCache is an in-memory Cache which evicts the least recently used entry when full
+type LRUCache struct {
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCache creates an in-memory cache holding at most capacity entries
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		capacity = DefaultCacheSize
+	}
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// Get returns the entry for key and marks it as recently used
+func (c *LRUCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruItem).entry, true
+}
+
+// Set stores the entry for key, evicting the least recently used entry if the cache is full
+func (c *LRUCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruItem{key: key, entry: entry})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+// Delete removes the entry for key
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of entries in the cache
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// FileCache is a Cache storing every entry as a JSON file in a directory
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates a cache in dir, creating the directory if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get reads the entry for key from disk
+func (c *FileCache) Get(key string) (CacheEntry, bool) {
+	content, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	var entry CacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Set writes the entry for key to disk. Write errors are ignored, the entry is simply not cached.
+func (c *FileCache) Set(key string, entry CacheEntry) {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	writeFileAtomic(c.path(key), content)
+}
+
+// writeFileAtomic writes to a temporary file first and renames it, so concurrent readers never see a partial file
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// Delete removes the entry for key from disk
+func (c *FileCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
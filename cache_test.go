@@ -0,0 +1,131 @@
+package takeawayapi
+
+import (
+	"testing"
+	"time"
+)
+
+const testCountriesResponse = `{"av":{"cd":[{"cy":"DE","nm":"lieferando.de"}],"cs":{"ct":[]}}}`
+
+func TestCacheTTL(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{"getcountriesdata": testCountriesResponse})
+	tac.EnableCache(CacheConfig{})
+
+	for i := 0; i < 3; i++ {
+		countries, err := tac.GetCountriesData()
+		if err != nil {
+			t.Fatalf(`GetCountriesData errored with error: %v`, err)
+		}
+		if len(countries.CountryData) != 1 {
+			t.Fatalf(`GetCountriesData returned wrong countries: %v`, countries.CountryData)
+		}
+	}
+	if ts.Hits("getcountriesdata") != 1 {
+		t.Fatalf(`Expected 1 upstream request, got %v`, ts.Hits("getcountriesdata"))
+	}
+
+	if _, err := tac.WithCacheMode(CacheBypass).GetCountriesData(); err != nil {
+		t.Fatalf(`GetCountriesData errored with error: %v`, err)
+	}
+	if _, err := tac.WithCacheMode(CacheRefresh).GetCountriesData(); err != nil {
+		t.Fatalf(`GetCountriesData errored with error: %v`, err)
+	}
+	if ts.Hits("getcountriesdata") != 3 {
+		t.Fatalf(`Expected 3 upstream requests after bypass and refresh, got %v`, ts.Hits("getcountriesdata"))
+	}
+}
+
+func TestCacheSkipsAPIErrors(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{"getcountriesdata": `{"nok":{"error":{"errorid":1,"errortext":"fail"}}}`})
+	tac.EnableCache(CacheConfig{})
+	for i := 0; i < 2; i++ {
+		if _, err := tac.GetCountriesData(); err == nil {
+			t.Fatalf(`GetCountriesData did not return the API error`)
+		}
+	}
+	if ts.Hits("getcountriesdata") != 2 {
+		t.Fatalf(`API errors must not be cached, got %v upstream requests`, ts.Hits("getcountriesdata"))
+	}
+}
+
+func TestCacheStaleWhileRevalidate(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{"getcountriesdata": testCountriesResponse})
+	cache := NewLRUCache(10)
+	tac.EnableCache(CacheConfig{
+		Cache:                cache,
+		TTLs:                 map[string]time.Duration{"getcountriesdata": time.Minute},
+		StaleWhileRevalidate: time.Hour,
+	})
+	if _, err := tac.GetCountriesData(); err != nil {
+		t.Fatalf(`GetCountriesData errored with error: %v`, err)
+	}
+
+	// Age the cached entry past its TTL
+	_, md5sum := tac.signRequest("getcountriesdata")
+	key := tac.cacheKey("getcountriesdata", md5sum)
+	entry, _ := cache.Get(key)
+	entry.StoredAt = time.Now().Add(-2 * time.Minute)
+	cache.Set(key, entry)
+
+	ts.SetResponse("getcountriesdata", `{"av":{"cd":[{"cy":"DE"},{"cy":"NL"}],"cs":{"ct":[]}}}`)
+	countries, err := tac.GetCountriesData()
+	if err != nil {
+		t.Fatalf(`GetCountriesData errored with error: %v`, err)
+	}
+	if len(countries.CountryData) != 1 {
+		t.Fatalf(`Expected the stale response, got %v`, countries.CountryData)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		entry, _ := cache.Get(key)
+		if time.Since(entry.StoredAt) < time.Minute {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf(`Stale entry was not revalidated`)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	countries, err = tac.GetCountriesData()
+	if err != nil {
+		t.Fatalf(`GetCountriesData errored with error: %v`, err)
+	}
+	if len(countries.CountryData) != 2 {
+		t.Fatalf(`Expected the revalidated response, got %v`, countries.CountryData)
+	}
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", CacheEntry{Body: []byte("a")})
+	cache.Set("b", CacheEntry{Body: []byte("b")})
+	cache.Get("a")
+	cache.Set("c", CacheEntry{Body: []byte("c")})
+	if _, ok := cache.Get("b"); ok {
+		t.Fatalf(`Least recently used entry was not evicted`)
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf(`Recently used entry was evicted`)
+	}
+	if cache.Len() != 2 {
+		t.Fatalf(`Expected 2 entries, got %v`, cache.Len())
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	cache, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf(`NewFileCache errored with error: %v`, err)
+	}
+	stored := CacheEntry{Body: []byte(`{"a":1}`), StoredAt: time.Now().Truncate(time.Second)}
+	cache.Set("key", stored)
+	entry, ok := cache.Get("key")
+	if !ok || string(entry.Body) != string(stored.Body) || !entry.StoredAt.Equal(stored.StoredAt) {
+		t.Fatalf(`FileCache returned wrong entry: %v`, entry)
+	}
+	cache.Delete("key")
+	if _, ok := cache.Get("key"); ok {
+		t.Fatalf(`FileCache entry was not deleted`)
+	}
+}
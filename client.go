@@ -0,0 +1,30 @@
+package takeawayapi
+
+// Client describes all API operations offered by TakeAwayClient.
+// Code depending on the API should accept a Client so tests can substitute a MockClient.
+type Client interface {
+	GetCurrentTime(cc CountryCode, RestaurantID string, OrderingMode int) (CurrentTimeResponse, error)
+	GetRestaurants(postalCode string, cc CountryCode, latitude string, longitude string) (RestaurantsResponse, error)
+	GetCountriesData() (AvailableCountries, error)
+	GetRestaurantData(restaurantId string, postcode string, cc CountryCode, latitude string, longitude string, clientID string) (RestaurantData, error)
+	GetRestaurantCheckoutData(restaurantId string, postcode string, cc CountryCode, latitude string, longitude string, clientID string) (RestaurantData, error)
+	GetRestaurantReviews(restaurantID string, page int) ([]Review, error)
+	GetGeoLocationData(latitude string, longitude string) (LocationData, error)
+	PlaceOrder(order OrderRequest) (OrderResult, error)
+	GetOrderStatus(orderID string) (OrderStatus, error)
+	Login() (Session, error)
+	Logout() error
+	GetOrderHistory(page int) (OrderHistoryPage, error)
+	GetAddresses() ([]SavedAddress, error)
+	AddAddress(address SavedAddress) (SavedAddress, error)
+	UpdateAddress(address SavedAddress) (SavedAddress, error)
+	DeleteAddress(addressID string) error
+	ValidateVoucher(code string, restaurantID string) (Voucher, error)
+	GetStampCards() ([]StampCard, error)
+	GetFavorites() ([]Restaurant, error)
+	AddFavorite(restaurantID string) error
+	RemoveFavorite(restaurantID string) error
+}
+
+// Make sure TakeAwayClient always implements Client
+var _ Client = (*TakeAwayClient)(nil)
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/philmacfly/takeawayapi"
+)
+
+// newFlags creates the flag set of a command, errors are returned by parse instead of printed
+func newFlags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// parse parses the arguments of a command and checks the number of positional arguments
+func parse(flags *flag.FlagSet, args []string, positional int) error {
+	if err := flags.Parse(args); err != nil {
+		return usagef("%v", err)
+	}
+	if flags.NArg() != positional {
+		return usagef("%s expects %d argument(s), got %d", flags.Name(), positional, flags.NArg())
+	}
+	return nil
+}
+
+// location are the flags selecting the delivery location
+type location struct {
+	postcode  *string
+	latitude  *string
+	longitude *string
+}
+
+func locationFlags(flags *flag.FlagSet) location {
+	return location{
+		postcode:  flags.String("postcode", "", "delivery postcode"),
+		latitude:  flags.String("lat", "", "delivery latitude"),
+		longitude: flags.String("lng", "", "delivery longitude"),
+	}
+}
+
+// restaurantData parses the arguments of a command showing a single restaurant and requests its data
+func restaurantData(cfg config, name string, args []string, checkout bool) (takeawayapi.RestaurantData, error) {
+	flags := newFlags(name)
+	loc := locationFlags(flags)
+	if err := parse(flags, args, 1); err != nil {
+		return takeawayapi.RestaurantData{}, err
+	}
+	if checkout {
+		return cfg.client.GetRestaurantCheckoutData(flags.Arg(0), *loc.postcode, cfg.country, *loc.latitude, *loc.longitude, "")
+	}
+	return cfg.client.GetRestaurantData(flags.Arg(0), *loc.postcode, cfg.country, *loc.latitude, *loc.longitude, "")
+}
+
+func runCountries(cfg config, args []string) error {
+	if err := parse(newFlags("countries"), args, 0); err != nil {
+		return err
+	}
+	countries, err := cfg.client.GetCountriesData()
+	if err != nil {
+		return err
+	}
+	r := result{value: countries.CountryData, header: []string{"COUNTRY", "NAME", "DOMAIN", "API DOMAIN", "LANGUAGES"}}
+	for _, country := range countries.CountryData {
+		r.rows = append(r.rows, []string{country.CountryA2, country.Name, country.Domain, country.APIDomain, strings.Join(country.Ls.La, " ")})
+	}
+	return cfg.write(r)
+}
+
+func runRestaurants(cfg config, args []string) error {
+	flags := newFlags("restaurants")
+	loc := locationFlags(flags)
+	if err := parse(flags, args, 0); err != nil {
+		return err
+	}
+	if *loc.postcode == "" && (*loc.latitude == "" || *loc.longitude == "") {
+		return usagef("restaurants needs -postcode or -lat and -lng")
+	}
+	restaurants, err := cfg.client.GetRestaurants(*loc.postcode, cfg.country, *loc.latitude, *loc.longitude)
+	if err != nil {
+		return err
+	}
+	r := result{value: restaurants.Restaurants, header: []string{"ID", "NAME", "CUISINES", "RATING", "DELIVERY", "PICKUP", "MINIMUM ORDER", "DELIVERY FEE"}}
+	for _, restaurant := range restaurants.Restaurants {
+		var cuisines []string
+		for _, cuisine := range restaurant.Cuisines() {
+			cuisines = append(cuisines, cuisine.Name)
+		}
+		minimum, fee := "", ""
+		if amount, err := restaurant.MinimumOrder(); err == nil {
+			minimum = amount.String()
+		}
+		if amount, err := restaurant.DeliveryFee(); err == nil {
+			fee = amount.String()
+		}
+		r.rows = append(r.rows, []string{
+			restaurant.ID,
+			restaurant.Name,
+			strings.Join(cuisines, ", "),
+			strconv.FormatFloat(restaurant.Rating().Stars, 'f', 1, 64),
+			restaurant.Dm.Delivery.State.String(),
+			restaurant.Dm.Pickup.State.String(),
+			minimum,
+			fee,
+		})
+	}
+	return cfg.write(r)
+}
+
+func runRestaurant(cfg config, args []string) error {
+	restaurant, err := restaurantData(cfg, "restaurant", args, false)
+	if err != nil {
+		return err
+	}
+	rating := restaurant.Rating()
+	address := restaurant.Ad
+	r := result{value: restaurant, header: []string{"FIELD", "VALUE"}, rows: [][]string{
+		{"id", restaurant.RestaurantID},
+		{"name", restaurant.Name},
+		{"branch", restaurant.Branch},
+		{"address", strings.TrimSpace(fmt.Sprintf("%s %s, %s %s", address.Street, address.Housenumber, address.Postcode, address.City))},
+		{"phone", restaurant.TelephoneNumbers.No1},
+		{"rating", fmt.Sprintf("%.1f (%d reviews)", rating.Stars, rating.ReviewCount)},
+		{"minimum order", restaurant.Dc.Ma},
+		{"delivery", restaurant.Dm.Delivery.State.String()},
+		{"pickup", restaurant.Dm.Pickup.State.String()},
+		{"products", strconv.Itoa(len(restaurant.Products()))},
+	}}
+	return cfg.write(r)
+}
+
+func runMenu(cfg config, args []string) error {
+	restaurant, err := restaurantData(cfg, "menu", args, false)
+	if err != nil {
+		return err
+	}
+	r := result{value: restaurant.Menu, header: []string{"CATEGORY", "ID", "PRODUCT", "DESCRIPTION", "PICKUP", "DELIVERY"}}
+	for _, category := range restaurant.Menu.CategorieStruct.Categories {
+		for _, product := range category.ProductStruct.Products {
+			r.rows = append(r.rows, []string{category.Name, product.ID, product.Name, product.Description, product.PickupCost, product.DeliveryCost})
+		}
+	}
+	return cfg.write(r)
+}
+
+func runCheckout(cfg config, args []string) error {
+	restaurant, err := restaurantData(cfg, "checkout", args, true)
+	if err != nil {
+		return err
+	}
+	options, err := restaurant.PaymentOptions()
+	if err != nil {
+		return err
+	}
+	r := result{value: restaurant, header: []string{"TYPE", "NAME", "DETAILS"}}
+	for _, option := range options {
+		r.rows = append(r.rows, []string{"payment", option.Method.String(), option.Name})
+	}
+	for _, area := range restaurant.DeliveryData.Da {
+		r.rows = append(r.rows, []string{"delivery area", strings.Join(area.Postcodes.PostCodesArray, " "), "minimum order " + area.Ma})
+	}
+	return cfg.write(r)
+}
+
+func runReviews(cfg config, args []string) error {
+	flags := newFlags("reviews")
+	page := flags.Int("page", 1, "page of the reviews")
+	if err := parse(flags, args, 1); err != nil {
+		return err
+	}
+	reviews, err := cfg.client.GetRestaurantReviews(flags.Arg(0), *page)
+	if err != nil {
+		return err
+	}
+	r := result{value: reviews, header: []string{"TIME", "NAME", "REVIEW"}}
+	for _, review := range reviews {
+		r.rows = append(r.rows, []string{review.TimeStr, review.Name, review.Remark})
+	}
+	return cfg.write(r)
+}
+
+func runTime(cfg config, args []string) error {
+	flags := newFlags("time")
+	restaurantID := flags.String("restaurant", "", "restaurant ID")
+	mode := flags.Int("mode", int(takeawayapi.OrderModeDelivery), "ordering mode, 1 for delivery and 2 for pickup")
+	if err := parse(flags, args, 0); err != nil {
+		return err
+	}
+	currentTime, err := cfg.client.GetCurrentTime(cfg.country, *restaurantID, *mode)
+	if err != nil {
+		return err
+	}
+	r := result{value: currentTime, header: []string{"CURRENT TIME"}, rows: [][]string{{currentTime.CurrentTimeStr}}}
+	return cfg.write(r)
+}
@@ -0,0 +1,179 @@
+// Command takeaway queries the Takeaway API from the command line.
+//
+//	takeaway [-country DE] [-lang de] [-format table|json|csv] <command> [arguments]
+//
+// Commands:
+//
+//	countries                                  list the available countries
+//	restaurants -postcode 90461                list the restaurants delivering to a postcode
+//	restaurants -lat 49.43 -lng 11.08          list the restaurants delivering to coordinates
+//	restaurant [-postcode 90461] <id>          show the details of a restaurant
+//	menu [-postcode 90461] <id>                show the menu of a restaurant
+//	checkout [-postcode 90461] <id>            show the payment methods and delivery areas of a restaurant
+//	reviews [-page 1] <id>                     list the reviews of a restaurant
+//	time [-restaurant id] [-mode 1]            show the current time of the API
+//
+// The exit code is 0 on success, 1 for other errors, 2 for invalid usage and
+// 3 for errors reported by the API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+
+	"github.com/philmacfly/takeawayapi"
+)
+
+// Exit codes
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitAPIError = 3
+)
+
+// usageError is returned for invalid arguments
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+func usagef(format string, args ...any) error {
+	return usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// config are the global options shared by all commands
+type config struct {
+	client  *takeawayapi.TakeAwayClient
+	country takeawayapi.CountryCode
+	format  string
+	out     io.Writer
+}
+
+// command is a subcommand, run receives the arguments after the command name
+type command struct {
+	usage string
+	run   func(cfg config, args []string) error
+}
+
+var commands = map[string]command{
+	"countries":   {usage: "countries", run: runCountries},
+	"restaurants": {usage: "restaurants (-postcode code | -lat lat -lng lng)", run: runRestaurants},
+	"restaurant":  {usage: "restaurant [-postcode code] [-lat lat -lng lng] <id>", run: runRestaurant},
+	"menu":        {usage: "menu [-postcode code] [-lat lat -lng lng] <id>", run: runMenu},
+	"checkout":    {usage: "checkout [-postcode code] [-lat lat -lng lng] <id>", run: runCheckout},
+	"reviews":     {usage: "reviews [-page n] <id>", run: runReviews},
+	"time":        {usage: "time [-restaurant id] [-mode 1|2]", run: runTime},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command line and returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("takeaway", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	country := flags.String("country", "DE", "two letter country code, e.g. DE, NL, AT or PL")
+	lang := flags.String("lang", "de", "language, also selects the API subdomain")
+	format := flags.String("format", "table", "output format: table, json or csv")
+	baseURL := flags.String("url", "", "API URL, defaults to the URL of the language")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of the whole command")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: takeaway [flags] <command> [arguments]")
+		fmt.Fprintln(stderr, "\ncommands:")
+		for _, name := range commandNames() {
+			fmt.Fprintln(stderr, "  "+commands[name].usage)
+		}
+		fmt.Fprintln(stderr, "\nflags:")
+		flags.PrintDefaults()
+		fmt.Fprintln(stderr, "\nexit codes:")
+		fmt.Fprintln(stderr, "  0  success")
+		fmt.Fprintln(stderr, "  1  other errors, e.g. network errors")
+		fmt.Fprintln(stderr, "  2  invalid usage")
+		fmt.Fprintln(stderr, "  3  error reported by the API, the API error ID is printed with the message")
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	cc, err := takeawayapi.ParseCountryCode(*country)
+	if err != nil {
+		fmt.Fprintf(stderr, "takeaway: %v\n", err)
+		return exitUsage
+	}
+	if !slices.Contains([]string{"table", "json", "csv"}, *format) {
+		fmt.Fprintf(stderr, "takeaway: unknown format %q\n", *format)
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "takeaway: unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		return exitUsage
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	client := takeawayapi.NewClient(*lang)
+	if *baseURL != "" {
+		client.BaseURL = *baseURL
+	}
+	cfg := config{client: client.WithContext(ctx), country: cc, format: *format, out: stdout}
+
+	err = cmd.run(cfg, flags.Args()[1:])
+	if err == nil {
+		return exitOK
+	}
+	fmt.Fprintf(stderr, "takeaway: %v\n", err)
+	var usage usageError
+	if errors.As(err, &usage) {
+		fmt.Fprintln(stderr, "usage: takeaway [flags] "+cmd.usage)
+	}
+	return exitCode(err)
+}
+
+// exitCode returns the exit code for an error. All API errors share exitAPIError,
+// the API doesn't document its error IDs so they aren't mapped to codes of their own.
+func exitCode(err error) int {
+	var usage usageError
+	var apiError *takeawayapi.APIError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &usage):
+		return exitUsage
+	case errors.As(err, &apiError):
+		return exitAPIError
+	}
+	return exitError
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
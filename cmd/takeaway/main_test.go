@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newTestServer answers API functions with canned JSON responses
+func newTestServer(t *testing.T, responses map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		response, ok := responses[r.PostForm.Get("var1")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func runTest(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunOutput(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"getcountriesdata":  `{"av":{"cd":[{"cy":"DE","nm":"lieferando.de","su":"de.citymeal.com","tw":"Lieferando","ls":{"la":["de","en"]}}]}}`,
+		"restaurantreviews": `{"rr":{"rv":[{"nm":"Max","ti":"2024-01-01 12:00:00","rm":"Sehr\tlecker"}]}}`,
+	})
+	tests := map[string]struct {
+		args []string
+		want string
+	}{
+		"csv":   {args: []string{"-format", "csv", "countries"}, want: "COUNTRY,NAME,DOMAIN,API DOMAIN,LANGUAGES\nDE,Lieferando,lieferando.de,de.citymeal.com,de en\n"},
+		"json":  {args: []string{"--format=json", "countries"}, want: `"su": "de.citymeal.com"`},
+		"table": {args: []string{"reviews", "-page", "2", "R1"}, want: "2024-01-01 12:00:00  Max   Sehr lecker\n"},
+	}
+	for name, test := range tests {
+		code, stdout, stderr := runTest(t, append([]string{"-url", server.URL}, test.args...)...)
+		if code != exitOK {
+			t.Errorf(`%s: Expected exit code 0, got %d: %s`, name, code, stderr)
+		}
+		if !strings.Contains(stdout, test.want) {
+			t.Errorf("%s: Expected %q in output:\n%s", name, test.want, stdout)
+		}
+	}
+}
+
+func TestRunExitCodes(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"getcountriesdata":  `{"nok":{"error":{"errorid":12,"errortext":"Unknown"}}}`,
+		"restaurantreviews": `{"nok":{"error":{"errorid":401,"errortext":"Session expired"}}}`,
+	})
+	tests := map[string]struct {
+		args []string
+		want int
+	}{
+		"api error":       {args: []string{"countries"}, want: exitAPIError},
+		"api error id":    {args: []string{"reviews", "R1"}, want: exitAPIError},
+		"http error":      {args: []string{"time"}, want: exitError},
+		"no command":      {args: []string{}, want: exitUsage},
+		"unknown command": {args: []string{"pizza"}, want: exitUsage},
+		"unknown country": {args: []string{"-country", "XX", "countries"}, want: exitUsage},
+		"unknown format":  {args: []string{"-format", "xml", "countries"}, want: exitUsage},
+		"missing id":      {args: []string{"menu"}, want: exitUsage},
+		"missing place":   {args: []string{"restaurants"}, want: exitUsage},
+		"unknown flag":    {args: []string{"reviews", "-stars", "5", "R1"}, want: exitUsage},
+	}
+	for name, test := range tests {
+		code, _, stderr := runTest(t, append([]string{"-url", server.URL}, test.args...)...)
+		if code != test.want {
+			t.Errorf(`%s: Expected exit code %d, got %d: %s`, name, test.want, code, stderr)
+		}
+	}
+}
+
+func TestRunUsageExitCodes(t *testing.T) {
+	code, _, stderr := runTest(t, "-h")
+	if code != exitOK {
+		t.Fatalf(`Expected exit code 0 for -h, got %d`, code)
+	}
+	for _, line := range []string{"exit codes:", "2  invalid usage", "3  error reported by the API"} {
+		if !strings.Contains(stderr, line) {
+			t.Errorf("Expected %q in usage:\n%s", line, stderr)
+		}
+	}
+}
+
+func TestRunMenuMachineReadable(t *testing.T) {
+	server := newTestServer(t, map[string]string{"getrestaurantdata": `{"rd":{"nm":"Pizza Test","ri":"R1","ct":"2024-01-01 12:00:00","mc":{"cs":{"ct":[
+		{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8.00"}]}},
+		{"id":"c2","nm":"Nudeln","ps":{"pr":{"id":"p2","nm":"Ramen","ds":"Suppe","pc":"11.00","tc":"11.50"}}}]}}}}`})
+
+	code, stdout, stderr := runTest(t, "-url", server.URL, "-format", "json", "menu", "R1")
+	if code != exitOK {
+		t.Fatalf(`Expected exit code 0, got %d: %s`, code, stderr)
+	}
+	var menu struct {
+		CategorieStruct struct {
+			Categories []struct {
+				Name          string `json:"nm"`
+				ProductStruct struct {
+					Products []struct {
+						ID string `json:"id"`
+					} `json:"pr"`
+				} `json:"ps"`
+			} `json:"ct"`
+		} `json:"cs"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &menu); err != nil {
+		t.Fatalf("Failed to decode JSON output: %v\n%s", err, stdout)
+	}
+	var got []string
+	for _, category := range menu.CategorieStruct.Categories {
+		for _, product := range category.ProductStruct.Products {
+			got = append(got, category.Name+"/"+product.ID)
+		}
+	}
+	if want := []string{"Pizza/p1", "Nudeln/p2"}; !slices.Equal(got, want) {
+		t.Errorf(`JSON products = %v, want %v`, got, want)
+	}
+
+	code, stdout, stderr = runTest(t, "-url", server.URL, "-format", "csv", "menu", "R1")
+	if code != exitOK {
+		t.Fatalf(`Expected exit code 0, got %d: %s`, code, stderr)
+	}
+	want := "CATEGORY,ID,PRODUCT,DESCRIPTION,PICKUP,DELIVERY\n" +
+		"Pizza,p1,Margherita,,7.50,8.00\n" +
+		"Nudeln,p2,Ramen,Suppe,11.00,11.50\n"
+	if stdout != want {
+		t.Errorf("CSV output = %q, want %q", stdout, want)
+	}
+}
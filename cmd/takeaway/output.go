@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// result is the output of a command. JSON prints value, table and CSV print header and rows.
+type result struct {
+	value  any
+	header []string
+	rows   [][]string
+}
+
+// write prints the result in the configured format
+func (cfg config) write(r result) error {
+	switch cfg.format {
+	case "json":
+		encoder := json.NewEncoder(cfg.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r.value)
+	case "csv":
+		writer := csv.NewWriter(cfg.out)
+		if err := writer.Write(r.header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(r.rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	}
+	writer := tabwriter.NewWriter(cfg.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(r.header, "\t"))
+	for _, row := range r.rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			// Tabs and line breaks would break the table
+			cells[i] = strings.Join(strings.Fields(cell), " ")
+		}
+		fmt.Fprintln(writer, strings.Join(cells, "\t"))
+	}
+	return writer.Flush()
+}
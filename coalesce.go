@@ -0,0 +1,92 @@
+package takeawayapi
+
+import (
+	"context"
+	"net/url"
+	"sync"
+)
+
+// uncoalescedFunctions change state on the server, identical calls must each be sent
+var uncoalescedFunctions = map[string]bool{
+	"placeorder":     true,
+	"login":          true,
+	"logout":         true,
+	"addaddress":     true,
+	"updateaddress":  true,
+	"deleteaddress":  true,
+	"addfavorite":    true,
+	"removefavorite": true,
+}
+
+// flightGroup deduplicates concurrent identical requests so they share one upstream call
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: map[string]*flightCall{}}
+}
+
+// do runs request once for all concurrent callers with the same key.
+// Every caller waits until the result is ready or its own ctx is done.
+// The shared request is cancelled once all callers gave up waiting.
+func (g *flightGroup) do(ctx context.Context, key string, request func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		// The shared call must not be cancelled together with the first caller
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go func() {
+			call.body, call.err = request(callCtx)
+			cancel()
+			g.mu.Lock()
+			g.forget(key, call)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.body, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			g.forget(key, call)
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes call from the group so new callers start a new request. g.mu must be held.
+func (g *flightGroup) forget(key string, call *flightCall) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// upstream sends the request to the API, sharing the call with concurrent identical requests
+func (tac *TakeAwayClient) upstream(ctx context.Context, function string, key string, data url.Values) ([]byte, error) {
+	if tac.flights == nil || uncoalescedFunctions[function] {
+		return tac.doRequest(ctx, data)
+	}
+	return tac.flights.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		return tac.doRequest(ctx, data)
+	})
+}
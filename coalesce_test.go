@@ -0,0 +1,62 @@
+package takeawayapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRequestCoalescing(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{"getcountriesdata": testCountriesResponse})
+	ts.SetDelay(100 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			countries, err := tac.GetCountriesData()
+			if err == nil && len(countries.CountryData) != 1 {
+				err = errors.New("wrong countries")
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf(`GetCountriesData errored with error: %v`, err)
+		}
+	}
+	if ts.Hits("getcountriesdata") != 1 {
+		t.Fatalf(`Expected 1 upstream request, got %v`, ts.Hits("getcountriesdata"))
+	}
+}
+
+func TestRequestCoalescingContext(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{"getcountriesdata": testCountriesResponse})
+	ts.SetDelay(200 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		_, err := tac.GetCountriesData()
+		done <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+	if _, err := tac.WithContext(ctx).GetCountriesData(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf(`Expected deadline exceeded for cancelled caller, got %v`, err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf(`Cancelling one caller affected the other: %v`, err)
+	}
+	if ts.Hits("getcountriesdata") != 1 {
+		t.Fatalf(`Expected 1 upstream request, got %v`, ts.Hits("getcountriesdata"))
+	}
+}
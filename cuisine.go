@@ -0,0 +1,130 @@
+package takeawayapi
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Cuisine is a cuisine category of a restaurant. The name is localized in the language of the request.
+type Cuisine struct {
+	ID   string
+	Name string
+}
+
+// Cuisines returns the cuisine categories of the restaurant.
+// Each entry of Cs.Ct is sent as [id, name], entries with a single value use it as ID and name.
+func (r Restaurant) Cuisines() []Cuisine {
+	cuisines := make([]Cuisine, 0, len(r.Cs.Ct))
+	for _, category := range r.Cs.Ct {
+		switch len(category) {
+		case 0:
+			continue
+		case 1:
+			cuisines = append(cuisines, Cuisine{ID: category[0], Name: category[0]})
+		default:
+			cuisines = append(cuisines, Cuisine{ID: category[0], Name: category[1]})
+		}
+	}
+	return cuisines
+}
+
+// CuisineCatalog collects the cuisines seen in the restaurant lists of a country.
+// It is safe for concurrent use.
+type CuisineCatalog struct {
+	Country CountryCode
+
+	mu       sync.RWMutex
+	cuisines map[string]Cuisine
+}
+
+// NewCuisineCatalog creates a catalog for the country from the given restaurant lists
+func NewCuisineCatalog(cc CountryCode, responses ...RestaurantsResponse) *CuisineCatalog {
+	catalog := &CuisineCatalog{Country: cc, cuisines: map[string]Cuisine{}}
+	for _, response := range responses {
+		catalog.Add(response)
+	}
+	return catalog
+}
+
+// Add adds the cuisines of all restaurants in the response to the catalog
+func (c *CuisineCatalog) Add(response RestaurantsResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, restaurant := range response.Restaurants {
+		for _, cuisine := range restaurant.Cuisines() {
+			c.cuisines[cuisine.ID] = cuisine
+		}
+	}
+}
+
+// Lookup returns the cuisine with the given ID
+func (c *CuisineCatalog) Lookup(id string) (Cuisine, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cuisine, ok := c.cuisines[id]
+	return cuisine, ok
+}
+
+// Find returns the cuisine with the given name, compared case-insensitively
+func (c *CuisineCatalog) Find(name string) (Cuisine, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, cuisine := range c.cuisines {
+		if strings.EqualFold(cuisine.Name, name) {
+			return cuisine, true
+		}
+	}
+	return Cuisine{}, false
+}
+
+// All returns all cuisines sorted by name
+func (c *CuisineCatalog) All() []Cuisine {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cuisines := make([]Cuisine, 0, len(c.cuisines))
+	for _, cuisine := range c.cuisines {
+		cuisines = append(cuisines, cuisine)
+	}
+	slices.SortFunc(cuisines, func(a, b Cuisine) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return cuisines
+}
+
+// CuisineFacet is the number of restaurants offering a cuisine
+type CuisineFacet struct {
+	Cuisine Cuisine
+	Count   int
+}
+
+// CuisineFacets counts the restaurants per cuisine, the most common cuisine first
+func CuisineFacets(response RestaurantsResponse) []CuisineFacet {
+	counts := map[string]*CuisineFacet{}
+	for _, restaurant := range response.Restaurants {
+		seen := map[string]bool{}
+		for _, cuisine := range restaurant.Cuisines() {
+			// Count every restaurant only once per cuisine
+			if seen[cuisine.ID] {
+				continue
+			}
+			seen[cuisine.ID] = true
+			if facet, ok := counts[cuisine.ID]; ok {
+				facet.Count++
+			} else {
+				counts[cuisine.ID] = &CuisineFacet{Cuisine: cuisine, Count: 1}
+			}
+		}
+	}
+	facets := make([]CuisineFacet, 0, len(counts))
+	for _, facet := range counts {
+		facets = append(facets, *facet)
+	}
+	slices.SortFunc(facets, func(a, b CuisineFacet) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Cuisine.Name, b.Cuisine.Name)
+	})
+	return facets
+}
@@ -0,0 +1,23 @@
+package takeawayapi
+
+import "testing"
+
+func TestCuisineCatalog(t *testing.T) {
+	restaurants := testRestaurants(t)
+	catalog := NewCuisineCatalog(DE, restaurants)
+	all := catalog.All()
+	if len(all) != 4 || all[0].Name != "Burger" {
+		t.Fatalf(`Catalog contains wrong cuisines: %v`, all)
+	}
+	if cuisine, ok := catalog.Lookup("1"); !ok || cuisine.Name != "Pizza" {
+		t.Fatalf(`Lookup returned %v, %v`, cuisine, ok)
+	}
+	if cuisine, ok := catalog.Find("sushi"); !ok || cuisine.ID != "3" {
+		t.Fatalf(`Find returned %v, %v`, cuisine, ok)
+	}
+
+	facets := CuisineFacets(restaurants)
+	if len(facets) != 4 || facets[0].Cuisine.Name != "Pizza" || facets[0].Count != 2 {
+		t.Fatalf(`CuisineFacets returned wrong facets: %v`, facets)
+	}
+}
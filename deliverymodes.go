@@ -0,0 +1,119 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OperatingState is the state of a delivery mode ("op")
+type OperatingState int
+
+const (
+	// OperatingClosed means the mode can not be ordered right now
+	OperatingClosed OperatingState = 0
+	// OperatingOpen means the mode can be ordered for as soon as possible
+	OperatingOpen OperatingState = 1
+	// OperatingPreOrder means the mode can only be ordered for a later time
+	OperatingPreOrder OperatingState = 2
+)
+
+func (s OperatingState) String() string {
+	switch s {
+	case OperatingClosed:
+		return "closed"
+	case OperatingOpen:
+		return "open"
+	case OperatingPreOrder:
+		return "pre-order only"
+	}
+	return fmt.Sprintf("OperatingState(%d)", int(s))
+}
+
+// EtaRange is the estimated time until an order arrives, sent as minutes by the API
+type EtaRange struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+func (e *EtaRange) UnmarshalJSON(data []byte) error {
+	var minutes struct {
+		Min json.Number `json:"min"`
+		Max json.Number `json:"max"`
+	}
+	// Restaurants without ETA send an empty array instead of an object
+	var empty []any
+	if err := json.Unmarshal(data, &empty); err == nil {
+		*e = EtaRange{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &minutes); err != nil {
+		return fmt.Errorf("failed to unmarshal 'eta': data=%s", string(data))
+	}
+	parse := func(number json.Number) (time.Duration, error) {
+		if number == "" {
+			return 0, nil
+		}
+		value, err := number.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("failed to unmarshal 'eta': data=%s", string(data))
+		}
+		return time.Duration(value * float64(time.Minute)), nil
+	}
+	var err error
+	if e.Min, err = parse(minutes.Min); err != nil {
+		return err
+	}
+	if e.Max, err = parse(minutes.Max); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (e EtaRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Min float64 `json:"min"`
+		Max float64 `json:"max"`
+	}{e.Min.Minutes(), e.Max.Minutes()})
+}
+
+// DeliveryMode is the state of delivery or pickup at a restaurant
+type DeliveryMode struct {
+	State OperatingState `json:"op"`
+	// OpeningHours is the next opening time as sent by the API ("oh")
+	OpeningHours string `json:"oh"`
+	// Mpt is kept as sent by the API ("mpt"), it looks like minutes ("30") but it is unknown what it
+	// measures, so it is not converted to a duration like Eta
+	Mpt string   `json:"mpt"`
+	Eta EtaRange `json:"eta"`
+}
+
+// Available reports whether the mode can be ordered now or for later
+func (dm DeliveryMode) Available() bool {
+	return dm.State == OperatingOpen || dm.State == OperatingPreOrder
+}
+
+// DeliveryModes are the delivery ("dl") and pickup ("pu") modes of a restaurant
+type DeliveryModes struct {
+	Ah       FlexString   `json:"ah"`
+	Delivery DeliveryMode `json:"dl"`
+	Pickup   DeliveryMode `json:"pu"`
+}
+
+// SupportsDelivery reports whether delivery can be ordered now or for later
+func (dm DeliveryModes) SupportsDelivery() bool {
+	return dm.Delivery.Available()
+}
+
+// SupportsPickup reports whether pickup can be ordered now or for later
+func (dm DeliveryModes) SupportsPickup() bool {
+	return dm.Pickup.Available()
+}
+
+// Mode returns the mode used for the given order mode
+func (dm DeliveryModes) Mode(mode OrderMode) DeliveryMode {
+	if mode == OrderModePickup {
+		return dm.Pickup
+	}
+	return dm.Delivery
+}
@@ -0,0 +1,29 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDeliveryModes(t *testing.T) {
+	var restaurant Restaurant
+	if err := json.Unmarshal([]byte(`{"id":"A","dm":{"ah":1,"dl":{"op":1,"oh":""},"pu":{"op":0,"oh":"17:00"}}}`), &restaurant); err != nil {
+		t.Fatalf(`Failed to decode restaurant: %v`, err)
+	}
+	if !restaurant.Dm.SupportsDelivery() || restaurant.Dm.SupportsPickup() || restaurant.Dm.Ah != "1" {
+		t.Fatalf(`Restaurant delivery modes decoded wrong: %+v`, restaurant.Dm)
+	}
+
+	var restaurantData RestaurantData
+	if err := json.Unmarshal([]byte(`{"ri":"A","dm":{"ah":"x","dl":{"op":2,"mpt":"30","eta":{"min":25,"max":"40"}},"pu":{"op":1,"eta":[]}}}`), &restaurantData); err != nil {
+		t.Fatalf(`Failed to decode restaurant data: %v`, err)
+	}
+	delivery := restaurantData.Dm.Mode(OrderModeDelivery)
+	if delivery.State != OperatingPreOrder || delivery.Eta.Min != 25*time.Minute || delivery.Eta.Max != 40*time.Minute {
+		t.Fatalf(`Restaurant data delivery mode decoded wrong: %+v`, delivery)
+	}
+	if !restaurantData.Dm.SupportsPickup() || restaurantData.Dm.Pickup.Eta != (EtaRange{}) || restaurantData.Dm.Ah != "x" {
+		t.Fatalf(`Restaurant data pickup mode decoded wrong: %+v`, restaurantData.Dm.Pickup)
+	}
+}
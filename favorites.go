@@ -0,0 +1,81 @@
+package takeawayapi
+
+import "fmt"
+
+type favoritesResponse struct {
+	Favorites struct {
+		Restaurants []Restaurant `json:"rt"`
+	} `json:"fv"`
+}
+
+// FavoriteRestaurant is a favorite of the logged in user as seen from a postcode
+type FavoriteRestaurant struct {
+	Restaurant Restaurant
+	// Delivers is true if the restaurant was listed by GetRestaurants for the postcode,
+	// Restaurant then contains the full listing including the current ETA
+	Delivers bool
+}
+
+// GetFavorites returns the favorite restaurants of the logged in user
+func (tac *TakeAwayClient) GetFavorites() ([]Restaurant, error) {
+	function := "getfavorites"
+	var favoritesResponse favoritesResponse
+	err := tac.sendRequest(function, &favoritesResponse)
+	if err != nil {
+		return []Restaurant{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return favoritesResponse.Favorites.Restaurants, nil
+}
+
+// AddFavorite adds the restaurant to the favorites of the logged in user
+func (tac *TakeAwayClient) AddFavorite(restaurantID string) error {
+	function := "addfavorite"
+	var addResponse struct{}
+	err := tac.sendRequest(function, &addResponse, restaurantID)
+	if err != nil {
+		return fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return nil
+}
+
+// RemoveFavorite removes the restaurant from the favorites of the logged in user
+func (tac *TakeAwayClient) RemoveFavorite(restaurantID string) error {
+	function := "removefavorite"
+	var removeResponse struct{}
+	err := tac.sendRequest(function, &removeResponse, restaurantID)
+	if err != nil {
+		return fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return nil
+}
+
+// GetFavoritesForPostcode returns the favorites of the logged in user enriched with the
+// restaurant listing for the postcode, so it is known which favorites currently deliver and their ETA
+func (tac *TakeAwayClient) GetFavoritesForPostcode(postcode string, cc CountryCode) ([]FavoriteRestaurant, error) {
+	favorites, err := tac.GetFavorites()
+	if err != nil {
+		return nil, err
+	}
+	restaurants, err := tac.GetRestaurants(postcode, cc, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return EnrichFavorites(favorites, restaurants), nil
+}
+
+// EnrichFavorites replaces the favorites by their listing in restaurants where available
+func EnrichFavorites(favorites []Restaurant, restaurants RestaurantsResponse) []FavoriteRestaurant {
+	listed := make(map[string]Restaurant, len(restaurants.Restaurants))
+	for _, restaurant := range restaurants.Restaurants {
+		listed[restaurant.ID] = restaurant
+	}
+	enriched := make([]FavoriteRestaurant, 0, len(favorites))
+	for _, favorite := range favorites {
+		if restaurant, ok := listed[favorite.ID]; ok {
+			enriched = append(enriched, FavoriteRestaurant{Restaurant: restaurant, Delivers: true})
+		} else {
+			enriched = append(enriched, FavoriteRestaurant{Restaurant: favorite})
+		}
+	}
+	return enriched
+}
@@ -0,0 +1,38 @@
+package takeawayapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetFavoritesForPostcode(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{
+		"getfavorites":   `{"fv":{"rt":[{"id":"A","nm":"Pizza A"},{"id":"B","nm":"Sushi B"}]}}`,
+		"getrestaurants": `{"rs":{"cp":{"ptd":"90461"},"rt":[{"id":"A","nm":"Pizza A","eta":{"min":30,"max":45}},{"id":"C","nm":"Burger C"}],"ct":"2024-01-01 12:00:00"}}`,
+		"addfavorite":    `{}`,
+		"removefavorite": `{}`,
+	})
+	favorites, err := tac.GetFavoritesForPostcode("90461", DE)
+	if err != nil {
+		t.Fatalf(`GetFavoritesForPostcode errored with error: %v`, err)
+	}
+	if len(favorites) != 2 {
+		t.Fatalf(`GetFavoritesForPostcode returned wrong favorites: %+v`, favorites)
+	}
+	if !favorites[0].Delivers || favorites[0].Restaurant.Eta.Max != 45*time.Minute {
+		t.Fatalf(`Favorite A was not enriched: %+v`, favorites[0])
+	}
+	if favorites[1].Delivers || favorites[1].Restaurant.Name != "Sushi B" {
+		t.Fatalf(`Favorite B must not deliver: %+v`, favorites[1])
+	}
+
+	if err := tac.AddFavorite("C"); err != nil {
+		t.Fatalf(`AddFavorite errored with error: %v`, err)
+	}
+	if err := tac.RemoveFavorite("A"); err != nil {
+		t.Fatalf(`RemoveFavorite errored with error: %v`, err)
+	}
+	if ts.LastForm("addfavorite").Get("var2") != "C" || ts.LastForm("removefavorite").Get("var2") != "A" {
+		t.Fatalf(`AddFavorite or RemoveFavorite sent wrong restaurant`)
+	}
+}
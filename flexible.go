@@ -0,0 +1,66 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FlexString is a string field the API sometimes sends as number, boolean or null
+type FlexString string
+
+func (fs *FlexString) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal string: data=%s", string(data))
+	}
+	switch v := value.(type) {
+	case nil:
+		*fs = ""
+	case string:
+		*fs = FlexString(v)
+	case float64:
+		*fs = FlexString(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		*fs = FlexString(strconv.FormatBool(v))
+	default:
+		return fmt.Errorf("failed to unmarshal string: data=%s", string(data))
+	}
+	return nil
+}
+
+// FlexFloat is a number field the API sometimes sends as string or null. Null and empty strings are zero.
+type FlexFloat float64
+
+func (ff *FlexFloat) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal number: data=%s", string(data))
+	}
+	switch v := value.(type) {
+	case nil:
+		*ff = 0
+	case float64:
+		*ff = FlexFloat(v)
+	case string:
+		v = strings.ReplaceAll(strings.TrimSpace(v), ",", ".")
+		if v == "" {
+			*ff = 0
+			return nil
+		}
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal number: data=%s", string(data))
+		}
+		*ff = FlexFloat(parsed)
+	case bool:
+		*ff = 0
+		if v {
+			*ff = 1
+		}
+	default:
+		return fmt.Errorf("failed to unmarshal number: data=%s", string(data))
+	}
+	return nil
+}
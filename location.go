@@ -0,0 +1,44 @@
+package takeawayapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// GetGeoLocationData resolves coordinates to the postcode and region used for delivery
+func (tac *TakeAwayClient) GetGeoLocationData(latitude string, longitude string) (LocationData, error) {
+	function := "getgeolocationdata"
+	var geoLocationDataResponse GeoLocationDataResponse
+	err := tac.sendRequest(function, &geoLocationDataResponse, latitude, longitude)
+	if err != nil {
+		return LocationData{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return geoLocationDataResponse.LocationData, nil
+}
+
+// postcodeFormats are the postcode formats of the countries served by the API
+var postcodeFormats = map[CountryCode]*regexp.Regexp{
+	NL: regexp.MustCompile(`^[1-9][0-9]{3} ?[A-Z]{2}$`),
+	DE: regexp.MustCompile(`^[0-9]{5}$`),
+	BE: regexp.MustCompile(`^[1-9][0-9]{3}$`),
+	AT: regexp.MustCompile(`^[1-9][0-9]{3}$`),
+	CH: regexp.MustCompile(`^[1-9][0-9]{3}$`),
+	LU: regexp.MustCompile(`^(L-)?[0-9]{4}$`),
+	PL: regexp.MustCompile(`^[0-9]{2}-?[0-9]{3}$`),
+	PT: regexp.MustCompile(`^[1-9][0-9]{3}(-[0-9]{3})?$`),
+	VN: regexp.MustCompile(`^[0-9]{6}$`),
+}
+
+// ValidatePostcode checks that postcode has the format used in the given country.
+// It does not check whether the postcode actually exists.
+func ValidatePostcode(postcode string, cc CountryCode) error {
+	format, ok := postcodeFormats[cc]
+	if !ok {
+		return fmt.Errorf("unknown country code: %d", cc)
+	}
+	if !format.MatchString(strings.ToUpper(strings.TrimSpace(postcode))) {
+		return fmt.Errorf("invalid postcode %q for country code %d", postcode, cc)
+	}
+	return nil
+}
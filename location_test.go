@@ -0,0 +1,45 @@
+package takeawayapi
+
+import "testing"
+
+func TestGetGeoLocationData(t *testing.T) {
+	tac, _ := newTestClient(t, map[string]string{"getgeolocationdata": `{"ld":{"pc":"90461","cy":"DE","tn":"Nürnberg","pr":"Bayern"}}`})
+	locationData, err := tac.GetGeoLocationData("49.43", "11.08")
+	if err != nil {
+		t.Fatalf(`GetGeoLocationData errored with error: %v`, err)
+	}
+	if locationData.PostCode != "90461" || locationData.CountryA2 != "DE" {
+		t.Fatalf(`GetGeoLocationData returned wrong location: %v`, locationData)
+	}
+}
+
+func TestValidatePostcode(t *testing.T) {
+	valid := map[CountryCode][]string{
+		DE: {"90461", " 18147 "},
+		NL: {"1012 AB", "1012ab"},
+		AT: {"1010"},
+		PL: {"00-950"},
+	}
+	for cc, postcodes := range valid {
+		for _, postcode := range postcodes {
+			if err := ValidatePostcode(postcode, cc); err != nil {
+				t.Fatalf(`ValidatePostcode rejected valid postcode: %v`, err)
+			}
+		}
+	}
+	invalid := map[CountryCode][]string{
+		DE: {"9046", "904611", "abcde"},
+		NL: {"0123 AB", "1012"},
+		PL: {"0950"},
+	}
+	for cc, postcodes := range invalid {
+		for _, postcode := range postcodes {
+			if err := ValidatePostcode(postcode, cc); err == nil {
+				t.Fatalf(`ValidatePostcode accepted invalid postcode %v for %v`, postcode, cc)
+			}
+		}
+	}
+	if err := ValidatePostcode("12345", CountryCode(99)); err == nil {
+		t.Fatalf(`ValidatePostcode accepted unknown country`)
+	}
+}
@@ -0,0 +1,79 @@
+package takeawayapi
+
+import "fmt"
+
+// ParticipatesInLoyalty reports whether the restaurant takes part in the stamp card program
+func (r Restaurant) ParticipatesInLoyalty() bool {
+	return r.Ply != 0
+}
+
+// ParticipatesInLoyalty reports whether the restaurant takes part in the stamp card program
+func (rd RestaurantData) ParticipatesInLoyalty() bool {
+	return rd.Ply != 0
+}
+
+type stampCardsResponse struct {
+	StampCards struct {
+		Cards []StampCard `json:"sc"`
+	} `json:"lc"`
+}
+
+// StampCard is the loyalty progress of the logged in user at a restaurant
+type StampCard struct {
+	RestaurantID   string `json:"ri"`
+	RestaurantName string `json:"rn"`
+	// Stamps is the number of stamps collected on the current card
+	Stamps int `json:"st"`
+	// StampsRequired is the number of stamps needed to complete a card
+	StampsRequired int `json:"rq"`
+	// MinimumOrder is the subtotal an order needs to earn a stamp
+	MinimumOrder Money `json:"mo"`
+	// Reward is the discount a completed card is worth
+	Reward Money `json:"rw"`
+	// CompletedCards is the number of completed cards not redeemed yet
+	CompletedCards int `json:"cc"`
+}
+
+// GetStampCards returns the stamp cards of the logged in user
+func (tac *TakeAwayClient) GetStampCards() ([]StampCard, error) {
+	function := "getstampcards"
+	var stampCardsResponse stampCardsResponse
+	err := tac.sendRequest(function, &stampCardsResponse)
+	if err != nil {
+		return []StampCard{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	return stampCardsResponse.StampCards.Cards, nil
+}
+
+// StampCardOutcome describes what an order would do to a stamp card
+type StampCardOutcome struct {
+	// EarnsStamp is true if the order is large enough to earn a stamp
+	EarnsStamp bool
+	// CompletesCard is true if the earned stamp completes the card
+	CompletesCard bool
+	// CanRedeem is true if a completed card can be redeemed on this order
+	CanRedeem bool
+	// StampsMissing is the number of stamps still needed after the order
+	StampsMissing int
+}
+
+// Evaluate tells what ordering the basket would do to the stamp card
+func (c StampCard) Evaluate(basket ValidatedBasket) StampCardOutcome {
+	if basket.Basket.RestaurantID != c.RestaurantID {
+		return StampCardOutcome{StampsMissing: c.StampsRequired - c.Stamps}
+	}
+	outcome := StampCardOutcome{
+		EarnsStamp: basket.Totals.Subtotal >= c.MinimumOrder,
+		CanRedeem:  c.CompletedCards > 0 && basket.Totals.Subtotal >= c.Reward,
+	}
+	stamps := c.Stamps
+	if outcome.EarnsStamp {
+		stamps++
+	}
+	outcome.CompletesCard = outcome.EarnsStamp && stamps >= c.StampsRequired
+	if outcome.CompletesCard {
+		stamps = 0
+	}
+	outcome.StampsMissing = c.StampsRequired - stamps
+	return outcome
+}
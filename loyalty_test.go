@@ -0,0 +1,37 @@
+package takeawayapi
+
+import "testing"
+
+func TestStampCards(t *testing.T) {
+	tac, _ := newTestClient(t, map[string]string{
+		"getstampcards": `{"lc":{"sc":[{"ri":"O3QQ11PN","rn":"Pizza Test","st":4,"rq":5,"mo":"15.00","rw":"6.00","cc":1}]}}`,
+	})
+	cards, err := tac.GetStampCards()
+	if err != nil {
+		t.Fatalf(`GetStampCards errored with error: %v`, err)
+	}
+	if len(cards) != 1 || cards[0].Stamps != 4 || cards[0].Reward != 600 {
+		t.Fatalf(`GetStampCards returned wrong cards: %+v`, cards)
+	}
+
+	basket := Basket{RestaurantID: "O3QQ11PN", Mode: OrderModePickup}
+	basket.Add("p3", 2)
+	validated, err := basket.Validate(testRestaurantData(t))
+	if err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+	outcome := cards[0].Evaluate(validated)
+	if !outcome.EarnsStamp || !outcome.CompletesCard || !outcome.CanRedeem || outcome.StampsMissing != 5 {
+		t.Fatalf(`Evaluate returned wrong outcome: %+v`, outcome)
+	}
+
+	basket.Items = []BasketItem{{ProductID: "p2", Quantity: 1}}
+	validated, err = basket.Validate(testRestaurantData(t))
+	if err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+	outcome = cards[0].Evaluate(validated)
+	if outcome.EarnsStamp || outcome.CompletesCard || outcome.StampsMissing != 1 {
+		t.Fatalf(`Small order must not earn a stamp: %+v`, outcome)
+	}
+}
@@ -0,0 +1,283 @@
+package takeawayapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MenuChangeKind is the kind of a change between two versions of a menu
+type MenuChangeKind int
+
+const (
+	MenuCategoryAdded MenuChangeKind = iota
+	MenuCategoryRemoved
+	MenuCategoryRenamed
+	MenuProductAdded
+	MenuProductRemoved
+	MenuProductRenamed
+	// MenuProductMoved means the product is listed in another category
+	MenuProductMoved
+	MenuProductPriceChanged
+	MenuSideDishAdded
+	MenuSideDishRemoved
+	MenuSideDishRenamed
+	MenuSideDishPriceChanged
+)
+
+var menuChangeKindNames = map[MenuChangeKind]string{
+	MenuCategoryAdded:        "category added",
+	MenuCategoryRemoved:      "category removed",
+	MenuCategoryRenamed:      "category renamed",
+	MenuProductAdded:         "product added",
+	MenuProductRemoved:       "product removed",
+	MenuProductRenamed:       "product renamed",
+	MenuProductMoved:         "product moved",
+	MenuProductPriceChanged:  "product price changed",
+	MenuSideDishAdded:        "side dish added",
+	MenuSideDishRemoved:      "side dish removed",
+	MenuSideDishRenamed:      "side dish renamed",
+	MenuSideDishPriceChanged: "side dish price changed",
+}
+
+func (k MenuChangeKind) String() string {
+	if name, ok := menuChangeKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("MenuChangeKind(%d)", int(k))
+}
+
+// MarshalText encodes the kind by its name
+func (k MenuChangeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// MenuChange is a single change between two versions of a menu.
+// Products and side dishes are matched by their ID, so a changed name is a rename.
+type MenuChange struct {
+	Kind       MenuChangeKind `json:"kind"`
+	CategoryID string         `json:"categoryId,omitempty"`
+	ProductID  string         `json:"productId,omitempty"`
+	SideDishID string         `json:"sideDishId,omitempty"`
+	// Name is the current name, or the last known name of removed entries
+	Name    string `json:"name"`
+	OldName string `json:"oldName,omitempty"`
+	// OldCategoryID is the previous category of a moved product
+	OldCategoryID string `json:"oldCategoryId,omitempty"`
+	// Mode is the price which changed for price changes
+	Mode     OrderMode `json:"mode,omitempty"`
+	OldPrice Money     `json:"oldPrice"`
+	NewPrice Money     `json:"newPrice"`
+}
+
+// String renders the change as a single line of text
+func (c MenuChange) String() string {
+	switch c.Kind {
+	case MenuCategoryAdded:
+		return fmt.Sprintf("+ category %s (%s)", c.Name, c.CategoryID)
+	case MenuCategoryRemoved:
+		return fmt.Sprintf("- category %s (%s)", c.Name, c.CategoryID)
+	case MenuCategoryRenamed:
+		return fmt.Sprintf("~ category %s (%s) renamed from %s", c.Name, c.CategoryID, c.OldName)
+	case MenuProductAdded:
+		return fmt.Sprintf("+ product %s (%s)", c.Name, c.ProductID)
+	case MenuProductRemoved:
+		return fmt.Sprintf("- product %s (%s)", c.Name, c.ProductID)
+	case MenuProductRenamed:
+		return fmt.Sprintf("~ product %s (%s) renamed from %s", c.Name, c.ProductID, c.OldName)
+	case MenuProductMoved:
+		return fmt.Sprintf("~ product %s (%s) moved from category %s to %s", c.Name, c.ProductID, c.OldCategoryID, c.CategoryID)
+	case MenuProductPriceChanged:
+		return fmt.Sprintf("~ product %s (%s) %s price %v -> %v", c.Name, c.ProductID, modeName(c.Mode), c.OldPrice, c.NewPrice)
+	case MenuSideDishAdded:
+		return fmt.Sprintf("+ side dish %s (%s) of product %s", c.Name, c.SideDishID, c.ProductID)
+	case MenuSideDishRemoved:
+		return fmt.Sprintf("- side dish %s (%s) of product %s", c.Name, c.SideDishID, c.ProductID)
+	case MenuSideDishRenamed:
+		return fmt.Sprintf("~ side dish %s (%s) of product %s renamed from %s", c.Name, c.SideDishID, c.ProductID, c.OldName)
+	case MenuSideDishPriceChanged:
+		return fmt.Sprintf("~ side dish %s (%s) of product %s %s price %v -> %v", c.Name, c.SideDishID, c.ProductID, modeName(c.Mode), c.OldPrice, c.NewPrice)
+	}
+	return c.Kind.String()
+}
+
+// modeName returns the name of the price used for the order mode
+func modeName(mode OrderMode) string {
+	if mode == OrderModePickup {
+		return "pickup"
+	}
+	return "delivery"
+}
+
+// MenuDiff is the set of changes between two versions of the menu of a restaurant
+type MenuDiff struct {
+	RestaurantID string       `json:"restaurantId"`
+	Changes      []MenuChange `json:"changes"`
+}
+
+// Empty reports whether the menus are the same
+func (d MenuDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// String renders the changes as text, one change per line
+func (d MenuDiff) String() string {
+	var builder strings.Builder
+	for _, change := range d.Changes {
+		builder.WriteString(change.String())
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// menuEntry is a product with the category it is listed in
+type menuEntry struct {
+	categoryID string
+	product    Product
+}
+
+// sideDishChoice is a single side dish option of a product
+type sideDishChoice struct {
+	ID           string
+	Name         string
+	PickupCost   string
+	DeliveryCost string
+}
+
+// menuEntries returns the products of the menu by ID and their IDs in menu order
+func menuEntries(restaurant RestaurantData) (map[string]menuEntry, []string) {
+	entries := map[string]menuEntry{}
+	var order []string
+	for _, category := range restaurant.Menu.CategorieStruct.Categories {
+		for _, product := range category.ProductStruct.Products {
+			if _, ok := entries[product.ID]; !ok {
+				order = append(order, product.ID)
+			}
+			entries[product.ID] = menuEntry{categoryID: category.ID, product: product}
+		}
+	}
+	return entries, order
+}
+
+// sideDishChoices returns the side dish options of the product by ID and their IDs in menu order
+func sideDishChoices(product Product) (map[string]sideDishChoice, []string) {
+	choices := map[string]sideDishChoice{}
+	var order []string
+	for _, sideDish := range product.SideItems.SideDishes {
+		for _, choice := range sideDish.Cc.Ch {
+			if _, ok := choices[choice.ID]; !ok {
+				order = append(order, choice.ID)
+			}
+			choices[choice.ID] = sideDishChoice{ID: choice.ID, Name: choice.Name, PickupCost: choice.PickupCost, DeliveryCost: choice.DeliveryCost}
+		}
+	}
+	return choices, order
+}
+
+// priceChanges returns a change for the pickup and the delivery price if they differ
+func priceChanges(change MenuChange, oldPickup, newPickup, oldDelivery, newDelivery string) []MenuChange {
+	var changes []MenuChange
+	for _, price := range []struct {
+		mode     OrderMode
+		old, new string
+	}{
+		{OrderModePickup, oldPickup, newPickup},
+		{OrderModeDelivery, oldDelivery, newDelivery},
+	} {
+		oldPrice, oldErr := ParseMoney(price.old)
+		newPrice, newErr := ParseMoney(price.new)
+		if oldErr != nil || newErr != nil || oldPrice == newPrice {
+			// Prices which can't be parsed are skipped instead of being reported as 0
+			continue
+		}
+		change.Mode, change.OldPrice, change.NewPrice = price.mode, oldPrice, newPrice
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// DiffMenus compares two versions of the menu of a restaurant.
+// Changes are reported in the order of the new menu, removed entries after the remaining ones.
+func DiffMenus(old, new RestaurantData) MenuDiff {
+	diff := MenuDiff{RestaurantID: new.RestaurantID}
+
+	oldCategories := map[string]string{}
+	for _, category := range old.Menu.CategorieStruct.Categories {
+		oldCategories[category.ID] = category.Name
+	}
+	newCategories := map[string]bool{}
+	for _, category := range new.Menu.CategorieStruct.Categories {
+		newCategories[category.ID] = true
+		oldName, ok := oldCategories[category.ID]
+		switch {
+		case !ok:
+			diff.Changes = append(diff.Changes, MenuChange{Kind: MenuCategoryAdded, CategoryID: category.ID, Name: category.Name})
+		case oldName != category.Name:
+			diff.Changes = append(diff.Changes, MenuChange{Kind: MenuCategoryRenamed, CategoryID: category.ID, Name: category.Name, OldName: oldName})
+		}
+	}
+	for _, category := range old.Menu.CategorieStruct.Categories {
+		if !newCategories[category.ID] {
+			diff.Changes = append(diff.Changes, MenuChange{Kind: MenuCategoryRemoved, CategoryID: category.ID, Name: category.Name})
+		}
+	}
+
+	oldProducts, oldOrder := menuEntries(old)
+	newProducts, newOrder := menuEntries(new)
+	for _, id := range newOrder {
+		entry := newProducts[id]
+		product := entry.product
+		oldEntry, ok := oldProducts[id]
+		if !ok {
+			diff.Changes = append(diff.Changes, MenuChange{Kind: MenuProductAdded, CategoryID: entry.categoryID, ProductID: id, Name: product.Name})
+			continue
+		}
+		oldProduct := oldEntry.product
+		if oldProduct.Name != product.Name {
+			diff.Changes = append(diff.Changes, MenuChange{Kind: MenuProductRenamed, CategoryID: entry.categoryID, ProductID: id, Name: product.Name, OldName: oldProduct.Name})
+		}
+		if oldEntry.categoryID != entry.categoryID {
+			diff.Changes = append(diff.Changes, MenuChange{Kind: MenuProductMoved, CategoryID: entry.categoryID, OldCategoryID: oldEntry.categoryID, ProductID: id, Name: product.Name})
+		}
+		diff.Changes = append(diff.Changes, priceChanges(
+			MenuChange{Kind: MenuProductPriceChanged, CategoryID: entry.categoryID, ProductID: id, Name: product.Name},
+			oldProduct.PickupCost, product.PickupCost, oldProduct.DeliveryCost, product.DeliveryCost)...)
+		diff.Changes = append(diff.Changes, diffSideDishes(entry.categoryID, oldProduct, product)...)
+	}
+	for _, id := range oldOrder {
+		if _, ok := newProducts[id]; !ok {
+			entry := oldProducts[id]
+			diff.Changes = append(diff.Changes, MenuChange{Kind: MenuProductRemoved, CategoryID: entry.categoryID, ProductID: id, Name: entry.product.Name})
+		}
+	}
+	return diff
+}
+
+// diffSideDishes compares the side dish options of two versions of a product
+func diffSideDishes(categoryID string, old, new Product) []MenuChange {
+	var changes []MenuChange
+	oldChoices, oldOrder := sideDishChoices(old)
+	newChoices, newOrder := sideDishChoices(new)
+	for _, id := range newOrder {
+		choice := newChoices[id]
+		change := MenuChange{CategoryID: categoryID, ProductID: new.ID, SideDishID: id, Name: choice.Name}
+		oldChoice, ok := oldChoices[id]
+		if !ok {
+			change.Kind = MenuSideDishAdded
+			changes = append(changes, change)
+			continue
+		}
+		if oldChoice.Name != choice.Name {
+			renamed := change
+			renamed.Kind, renamed.OldName = MenuSideDishRenamed, oldChoice.Name
+			changes = append(changes, renamed)
+		}
+		change.Kind = MenuSideDishPriceChanged
+		changes = append(changes, priceChanges(change, oldChoice.PickupCost, choice.PickupCost, oldChoice.DeliveryCost, choice.DeliveryCost)...)
+	}
+	for _, id := range oldOrder {
+		if _, ok := newChoices[id]; !ok {
+			changes = append(changes, MenuChange{Kind: MenuSideDishRemoved, CategoryID: categoryID, ProductID: new.ID, SideDishID: id, Name: oldChoices[id].Name})
+		}
+	}
+	return changes
+}
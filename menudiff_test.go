@@ -0,0 +1,168 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestDiffMenus(t *testing.T) {
+	const (
+		pizza      = `{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8.00"}]}}`
+		withExtras = `{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8.00",
+			"ss":{"sd":[{"nm":"Extras","cc":{"ch":[{"id":"s1","nm":"Käse","pc":"1.00","tc":"1.00"}]}}]}}]}}`
+	)
+	tests := []struct {
+		name     string
+		old, new []string
+		want     []MenuChange
+	}{
+		{
+			name: "unchanged",
+			old:  []string{pizza},
+			new:  []string{pizza},
+		},
+		{
+			name: "category added",
+			old:  []string{pizza},
+			new:  []string{pizza, `{"id":"c2","nm":"Salate"}`},
+			want: []MenuChange{{Kind: MenuCategoryAdded, CategoryID: "c2", Name: "Salate"}},
+		},
+		{
+			name: "category removed",
+			old:  []string{pizza, `{"id":"c2","nm":"Salate"}`},
+			new:  []string{pizza},
+			want: []MenuChange{{Kind: MenuCategoryRemoved, CategoryID: "c2", Name: "Salate"}},
+		},
+		{
+			name: "category renamed",
+			old:  []string{`{"id":"c1","nm":"Pizza"}`},
+			new:  []string{`{"id":"c1","nm":"Pizzen"}`},
+			want: []MenuChange{{Kind: MenuCategoryRenamed, CategoryID: "c1", Name: "Pizzen", OldName: "Pizza"}},
+		},
+		{
+			name: "product added",
+			old:  []string{`{"id":"c1","nm":"Pizza"}`},
+			new:  []string{pizza},
+			want: []MenuChange{{Kind: MenuProductAdded, CategoryID: "c1", ProductID: "p1", Name: "Margherita"}},
+		},
+		{
+			name: "product removed",
+			old:  []string{pizza},
+			new:  []string{`{"id":"c1","nm":"Pizza"}`},
+			want: []MenuChange{{Kind: MenuProductRemoved, CategoryID: "c1", ProductID: "p1", Name: "Margherita"}},
+		},
+		{
+			name: "product renamed",
+			old:  []string{pizza},
+			new:  []string{`{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita Classica","pc":"7.50","tc":"8.00"}]}}`},
+			want: []MenuChange{{Kind: MenuProductRenamed, CategoryID: "c1", ProductID: "p1", Name: "Margherita Classica", OldName: "Margherita"}},
+		},
+		{
+			name: "product moved",
+			old:  []string{pizza, `{"id":"c2","nm":"Klassiker"}`},
+			new:  []string{`{"id":"c1","nm":"Pizza"}`, `{"id":"c2","nm":"Klassiker","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8.00"}]}}`},
+			want: []MenuChange{{Kind: MenuProductMoved, CategoryID: "c2", OldCategoryID: "c1", ProductID: "p1", Name: "Margherita"}},
+		},
+		{
+			name: "product price changed",
+			old:  []string{pizza},
+			new:  []string{`{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8,50"}]}}`},
+			want: []MenuChange{{Kind: MenuProductPriceChanged, CategoryID: "c1", ProductID: "p1", Name: "Margherita", Mode: OrderModeDelivery, OldPrice: 800, NewPrice: 850}},
+		},
+		{
+			name: "product free",
+			old:  []string{pizza},
+			new:  []string{`{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"7.50","tc":"0"}]}}`},
+			want: []MenuChange{{Kind: MenuProductPriceChanged, CategoryID: "c1", ProductID: "p1", Name: "Margherita", Mode: OrderModeDelivery, OldPrice: 800, NewPrice: 0}},
+		},
+		{
+			name: "unparseable price skipped",
+			old:  []string{pizza},
+			new:  []string{`{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"n/a","tc":"8.00"}]}}`},
+		},
+		{
+			name: "side dish added",
+			old:  []string{pizza},
+			new:  []string{withExtras},
+			want: []MenuChange{{Kind: MenuSideDishAdded, CategoryID: "c1", ProductID: "p1", SideDishID: "s1", Name: "Käse"}},
+		},
+		{
+			name: "side dish removed",
+			old:  []string{withExtras},
+			new:  []string{pizza},
+			want: []MenuChange{{Kind: MenuSideDishRemoved, CategoryID: "c1", ProductID: "p1", SideDishID: "s1", Name: "Käse"}},
+		},
+		{
+			name: "side dish price changed",
+			old:  []string{withExtras},
+			new: []string{`{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8.00",
+				"ss":{"sd":[{"nm":"Extras","cc":{"ch":[{"id":"s1","nm":"Käse","pc":"1.00","tc":"1.50"}]}}]}}]}}`},
+			want: []MenuChange{{Kind: MenuSideDishPriceChanged, CategoryID: "c1", ProductID: "p1", SideDishID: "s1", Name: "Käse", Mode: OrderModeDelivery, OldPrice: 100, NewPrice: 150}},
+		},
+		{
+			name: "new menu order, removed entries last",
+			old:  []string{`{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita"},{"id":"p2","nm":"Salami"}]}}`},
+			new:  []string{`{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p3","nm":"Tonno"},{"id":"p1","nm":"Marinara"}]}}`},
+			want: []MenuChange{
+				{Kind: MenuProductAdded, CategoryID: "c1", ProductID: "p3", Name: "Tonno"},
+				{Kind: MenuProductRenamed, CategoryID: "c1", ProductID: "p1", Name: "Marinara", OldName: "Margherita"},
+				{Kind: MenuProductRemoved, CategoryID: "c1", ProductID: "p2", Name: "Salami"},
+			},
+		},
+	}
+	for _, test := range tests {
+		diff := DiffMenus(testMenu(t, "R1", test.old...), testMenu(t, "R1", test.new...))
+		if diff.RestaurantID != "R1" || !slices.Equal(diff.Changes, test.want) || diff.Empty() != (len(test.want) == 0) {
+			t.Errorf("%s: DiffMenus() =\n%s\nwant %+v", test.name, diff, test.want)
+		}
+	}
+}
+
+func TestMenuChangeString(t *testing.T) {
+	tests := []struct {
+		change MenuChange
+		want   string
+	}{
+		{MenuChange{Kind: MenuCategoryAdded, CategoryID: "c2", Name: "Salate"}, "+ category Salate (c2)"},
+		{MenuChange{Kind: MenuProductRemoved, ProductID: "p2", Name: "Salami"}, "- product Salami (p2)"},
+		{MenuChange{Kind: MenuProductMoved, ProductID: "p1", Name: "Margherita", OldCategoryID: "c1", CategoryID: "c2"}, "~ product Margherita (p1) moved from category c1 to c2"},
+		{MenuChange{Kind: MenuProductPriceChanged, ProductID: "p1", Name: "Margherita", Mode: OrderModeDelivery, OldPrice: 800, NewPrice: 850}, "~ product Margherita (p1) delivery price 8.00 -> 8.50"},
+		{MenuChange{Kind: MenuSideDishAdded, ProductID: "p1", SideDishID: "s2", Name: "Oliven"}, "+ side dish Oliven (s2) of product p1"},
+	}
+	for _, test := range tests {
+		if got := test.change.String(); got != test.want {
+			t.Errorf(`String() = %q, want %q`, got, test.want)
+		}
+	}
+
+	diff := MenuDiff{Changes: []MenuChange{tests[0].change, tests[1].change}}
+	if got, want := diff.String(), tests[0].want+"\n"+tests[1].want+"\n"; got != want {
+		t.Errorf(`MenuDiff.String() = %q, want %q`, got, want)
+	}
+}
+
+func TestMenuChangeJSON(t *testing.T) {
+	tests := []struct {
+		change MenuChange
+		want   string
+	}{
+		{
+			MenuChange{Kind: MenuProductPriceChanged, CategoryID: "c1", ProductID: "p1", Name: "Margherita", Mode: OrderModeDelivery, OldPrice: 800, NewPrice: 850},
+			`{"kind":"product price changed","categoryId":"c1","productId":"p1","name":"Margherita","mode":1,"oldPrice":"8.00","newPrice":"8.50"}`,
+		},
+		{
+			MenuChange{Kind: MenuProductPriceChanged, CategoryID: "c1", ProductID: "p1", Name: "Margherita", Mode: OrderModeDelivery, OldPrice: 800},
+			`{"kind":"product price changed","categoryId":"c1","productId":"p1","name":"Margherita","mode":1,"oldPrice":"8.00","newPrice":"0.00"}`,
+		},
+	}
+	for _, test := range tests {
+		encoded, err := json.Marshal(test.change)
+		if err != nil {
+			t.Fatalf(`Failed to encode change: %v`, err)
+		}
+		if string(encoded) != test.want {
+			t.Errorf(`json.Marshal() = %s, want %s`, encoded, test.want)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package takeawayapi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// MenuColumn is a column of an exported menu
+type MenuColumn int
+
+const (
+	ColumnCategory MenuColumn = iota
+	ColumnProduct
+	ColumnDescription
+	ColumnPickupPrice
+	ColumnDeliveryPrice
+	ColumnAllergens
+	ColumnSideDishes
+)
+
+var menuColumnNames = map[MenuColumn]string{
+	ColumnCategory:      "category",
+	ColumnProduct:       "product",
+	ColumnDescription:   "description",
+	ColumnPickupPrice:   "pickup_price",
+	ColumnDeliveryPrice: "delivery_price",
+	ColumnAllergens:     "allergens",
+	ColumnSideDishes:    "side_dishes",
+}
+
+func (c MenuColumn) String() string {
+	if name, ok := menuColumnNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("MenuColumn(%d)", int(c))
+}
+
+// DefaultMenuColumns are all columns in their default order
+var DefaultMenuColumns = []MenuColumn{ColumnCategory, ColumnProduct, ColumnDescription, ColumnPickupPrice, ColumnDeliveryPrice, ColumnAllergens, ColumnSideDishes}
+
+// PriceFormat describes how prices are written. The zero value writes prices like the API, e.g. "7.50".
+type PriceFormat struct {
+	DecimalSeparator   string
+	ThousandsSeparator string
+	Symbol             string
+	// SymbolFirst puts the symbol before the amount
+	SymbolFirst bool
+}
+
+// PriceFormatForCountry returns the usual price format of the country
+func PriceFormatForCountry(cc CountryCode) PriceFormat {
+	switch cc {
+	case NL, BE:
+		return PriceFormat{DecimalSeparator: ",", ThousandsSeparator: ".", Symbol: "€", SymbolFirst: true}
+	case DE, AT, LU, PT:
+		return PriceFormat{DecimalSeparator: ",", ThousandsSeparator: ".", Symbol: "€"}
+	case CH:
+		return PriceFormat{DecimalSeparator: ".", ThousandsSeparator: "'", Symbol: "CHF", SymbolFirst: true}
+	case PL:
+		return PriceFormat{DecimalSeparator: ",", ThousandsSeparator: " ", Symbol: "zł"}
+	case VN:
+		return PriceFormat{DecimalSeparator: ",", ThousandsSeparator: ".", Symbol: "₫"}
+	}
+	return PriceFormat{}
+}
+
+// Format writes the amount in the given format, e.g. "1.234,50 €"
+func (m Money) Format(format PriceFormat) string {
+	decimal := format.DecimalSeparator
+	if decimal == "" {
+		decimal = "."
+	}
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+	units := fmt.Sprint(int64(m / 100))
+	if format.ThousandsSeparator != "" {
+		var grouped []string
+		for len(units) > 3 {
+			grouped = append([]string{units[len(units)-3:]}, grouped...)
+			units = units[:len(units)-3]
+		}
+		units = strings.Join(append([]string{units}, grouped...), format.ThousandsSeparator)
+	}
+	amount := fmt.Sprintf("%s%s%s%02d", sign, units, decimal, m%100)
+	switch {
+	case format.Symbol == "":
+		return amount
+	case format.SymbolFirst:
+		return format.Symbol + " " + amount
+	}
+	return amount + " " + format.Symbol
+}
+
+// MenuExportOptions configures a menu export
+type MenuExportOptions struct {
+	// Columns are the exported columns in their order, defaults to DefaultMenuColumns
+	Columns     []MenuColumn
+	PriceFormat PriceFormat
+}
+
+func (o MenuExportOptions) columns() []MenuColumn {
+	if len(o.Columns) == 0 {
+		return DefaultMenuColumns
+	}
+	return o.Columns
+}
+
+// modes returns the order modes whose prices are exported, both if no price column is exported
+func (o MenuExportOptions) modes() []OrderMode {
+	var modes []OrderMode
+	if slices.Contains(o.columns(), ColumnPickupPrice) {
+		modes = append(modes, OrderModePickup)
+	}
+	if slices.Contains(o.columns(), ColumnDeliveryPrice) {
+		modes = append(modes, OrderModeDelivery)
+	}
+	if len(modes) == 0 {
+		return []OrderMode{OrderModePickup, OrderModeDelivery}
+	}
+	return modes
+}
+
+// MenuRow is a product of a flattened menu. Prices which can't be parsed are nil.
+type MenuRow struct {
+	Category      string
+	Product       string
+	Description   string
+	PickupPrice   *Money
+	DeliveryPrice *Money
+	Allergens     []string
+	SideDishes    []MenuSideDish
+}
+
+// MenuSideDish is a side dish option of a MenuRow. Prices which can't be parsed are nil.
+type MenuSideDish struct {
+	Name          string
+	PickupPrice   *Money
+	DeliveryPrice *Money
+}
+
+// Price returns the surcharge of the side dish for the order mode
+func (sd MenuSideDish) Price(mode OrderMode) *Money {
+	if mode == OrderModePickup {
+		return sd.PickupPrice
+	}
+	return sd.DeliveryPrice
+}
+
+// parsedPrice returns the parsed price or nil if it can't be parsed
+func parsedPrice(price Money, err error) *Money {
+	if err != nil {
+		return nil
+	}
+	return &price
+}
+
+// formatPrice formats a price of a MenuRow, unknown prices are empty
+func formatPrice(price *Money, format PriceFormat) string {
+	if price == nil {
+		return ""
+	}
+	return price.Format(format)
+}
+
+// MenuRows flattens the menu of the restaurant into one row per product
+func MenuRows(restaurant RestaurantData) []MenuRow {
+	var rows []MenuRow
+	for _, category := range restaurant.Menu.CategorieStruct.Categories {
+		for _, product := range category.ProductStruct.Products {
+			row := MenuRow{
+				Category:      category.Name,
+				Product:       product.Name,
+				Description:   product.Description,
+				PickupPrice:   parsedPrice(product.Price(OrderModePickup)),
+				DeliveryPrice: parsedPrice(product.Price(OrderModeDelivery)),
+				Allergens:     product.Fai.All,
+			}
+			for _, sideDish := range product.SideItems.SideDishes {
+				for _, choice := range sideDish.Cc.Ch {
+					row.SideDishes = append(row.SideDishes, MenuSideDish{
+						Name:          choice.Name,
+						PickupPrice:   parsedPrice(ParseMoney(choice.PickupCost)),
+						DeliveryPrice: parsedPrice(ParseMoney(choice.DeliveryCost)),
+					})
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// cell returns the text of a column of the row
+func (r MenuRow) cell(column MenuColumn, opts MenuExportOptions) string {
+	switch column {
+	case ColumnCategory:
+		return r.Category
+	case ColumnProduct:
+		return r.Product
+	case ColumnDescription:
+		return r.Description
+	case ColumnPickupPrice:
+		return formatPrice(r.PickupPrice, opts.PriceFormat)
+	case ColumnDeliveryPrice:
+		return formatPrice(r.DeliveryPrice, opts.PriceFormat)
+	case ColumnAllergens:
+		return strings.Join(r.Allergens, ", ")
+	case ColumnSideDishes:
+		options := make([]string, 0, len(r.SideDishes))
+		for _, option := range r.SideDishes {
+			options = append(options, option.label(opts))
+		}
+		return strings.Join(options, "; ")
+	}
+	return ""
+}
+
+// label returns the name of the side dish with its surcharge in the exported modes, e.g. "Extra Käse (+1,00 €)".
+// Different surcharges for pickup and delivery are both written, unknown and free surcharges are left out.
+func (sd MenuSideDish) label(opts MenuExportOptions) string {
+	modes := opts.modes()
+	named := len(modes) > 1 && (sd.PickupPrice == nil || sd.DeliveryPrice == nil || *sd.PickupPrice != *sd.DeliveryPrice)
+	var surcharges []string
+	for _, mode := range modes {
+		price := sd.Price(mode)
+		if price == nil || *price == 0 {
+			continue
+		}
+		surcharge := "+" + price.Format(opts.PriceFormat)
+		if named {
+			surcharge += " " + modeName(mode)
+		}
+		if !slices.Contains(surcharges, surcharge) {
+			surcharges = append(surcharges, surcharge)
+		}
+	}
+	if len(surcharges) == 0 {
+		return sd.Name
+	}
+	return fmt.Sprintf("%s (%s)", sd.Name, strings.Join(surcharges, ", "))
+}
+
+// ExportMenuCSV writes the menu as CSV with a header line
+func ExportMenuCSV(w io.Writer, restaurant RestaurantData, opts MenuExportOptions) error {
+	rows := MenuRows(restaurant)
+	columns := opts.columns()
+	writer := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.String()
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = row.cell(column, opts)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportMenuJSONLines writes the menu as one JSON object per product, keyed by the column names.
+// Allergens and side dishes are written as arrays.
+func ExportMenuJSONLines(w io.Writer, restaurant RestaurantData, opts MenuExportOptions) error {
+	rows := MenuRows(restaurant)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, row := range rows {
+		object := map[string]any{}
+		for _, column := range opts.columns() {
+			switch column {
+			case ColumnAllergens:
+				object[column.String()] = append([]string{}, row.Allergens...)
+			case ColumnSideDishes:
+				options := make([]map[string]string, 0, len(row.SideDishes))
+				for _, option := range row.SideDishes {
+					options = append(options, map[string]string{
+						"name":           option.Name,
+						"pickup_price":   formatPrice(option.PickupPrice, opts.PriceFormat),
+						"delivery_price": formatPrice(option.DeliveryPrice, opts.PriceFormat),
+					})
+				}
+				object[column.String()] = options
+			default:
+				object[column.String()] = row.cell(column, opts)
+			}
+		}
+		if err := encoder.Encode(object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markdownHeaders are the headers of the Markdown tables
+var markdownHeaders = map[MenuColumn]string{
+	ColumnCategory:      "Category",
+	ColumnProduct:       "Product",
+	ColumnDescription:   "Description",
+	ColumnPickupPrice:   "Pickup",
+	ColumnDeliveryPrice: "Delivery",
+	ColumnAllergens:     "Allergens",
+	ColumnSideDishes:    "Options",
+}
+
+// markdownCell escapes text for a Markdown table cell
+func markdownCell(text string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(text), " "), "|", `\|`)
+}
+
+// ExportMenuMarkdown writes the menu as a printable Markdown document titled with the restaurant name.
+// If the category column is exported every category gets its own heading and table.
+func ExportMenuMarkdown(w io.Writer, restaurant RestaurantData, opts MenuExportOptions) error {
+	rows := MenuRows(restaurant)
+	var columns []MenuColumn
+	grouped := false
+	for _, column := range opts.columns() {
+		if column == ColumnCategory {
+			grouped = true
+		} else {
+			columns = append(columns, column)
+		}
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# %s\n", markdownCell(restaurant.Name))
+	writeHeader := func() {
+		headers := make([]string, len(columns))
+		separators := make([]string, len(columns))
+		for i, column := range columns {
+			headers[i] = markdownHeaders[column]
+			separators[i] = "---"
+			if column == ColumnPickupPrice || column == ColumnDeliveryPrice {
+				separators[i] = "---:"
+			}
+		}
+		fmt.Fprintf(&builder, "\n| %s |\n| %s |\n", strings.Join(headers, " | "), strings.Join(separators, " | "))
+	}
+	if !grouped {
+		writeHeader()
+	}
+	for i, row := range rows {
+		if grouped && (i == 0 || rows[i-1].Category != row.Category) {
+			fmt.Fprintf(&builder, "\n## %s\n", markdownCell(row.Category))
+			writeHeader()
+		}
+		cells := make([]string, len(columns))
+		for j, column := range columns {
+			cells[j] = markdownCell(row.cell(column, opts))
+		}
+		fmt.Fprintf(&builder, "| %s |\n", strings.Join(cells, " | "))
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
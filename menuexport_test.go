@@ -0,0 +1,136 @@
+package takeawayapi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		amount Money
+		format PriceFormat
+		want   string
+	}{
+		{750, PriceFormat{}, "7.50"},
+		{123450, PriceFormatForCountry(DE), "1.234,50 €"},
+		{750, PriceFormatForCountry(NL), "€ 7,50"},
+		{123450, PriceFormatForCountry(CH), "CHF 1'234.50"},
+		{1234567800, PriceFormatForCountry(PL), "12 345 678,00 zł"},
+		{-5, PriceFormatForCountry(DE), "-0,05 €"},
+	}
+	for _, test := range tests {
+		if got := test.amount.Format(test.format); got != test.want {
+			t.Errorf(`Format(%d) = %q, want %q`, test.amount, got, test.want)
+		}
+	}
+}
+
+func TestExportMenuCSV(t *testing.T) {
+	restaurant := testMenu(t, "R1", `{"id":"c1","nm":"Pizza","ps":{"pr":[
+		{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8,00","fai":{"all":["A","G"]},
+			"ss":{"sd":[{"nm":"Extras","cc":{"ch":[{"id":"s1","nm":"Käse","pc":"1.00","tc":"1.00"}]}}]}},
+		{"id":"p2","nm":"Salami","pc":"8.50","tc":"9.00"}]}}`)
+	var buffer bytes.Buffer
+	opts := MenuExportOptions{
+		Columns:     []MenuColumn{ColumnProduct, ColumnDeliveryPrice, ColumnAllergens, ColumnSideDishes},
+		PriceFormat: PriceFormatForCountry(DE),
+	}
+	if err := ExportMenuCSV(&buffer, restaurant, opts); err != nil {
+		t.Fatalf(`Failed to export menu: %v`, err)
+	}
+	want := "product,delivery_price,allergens,side_dishes\n" +
+		"Margherita,\"8,00 €\",\"A, G\",\"Käse (+1,00 €)\"\n" +
+		"Salami,\"9,00 €\",,\n"
+	if got := buffer.String(); got != want {
+		t.Errorf("ExportMenuCSV() = %q, want %q", got, want)
+	}
+}
+
+func TestExportMenuJSONLines(t *testing.T) {
+	restaurant := testMenu(t, "R1", `{"id":"c1","nm":"Pizza","ps":{"pr":[
+		{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8.00","fai":{"all":["A","G"]},
+			"ss":{"sd":[{"nm":"Extras","cc":{"ch":[{"id":"s1","nm":"Käse","pc":"0.50","tc":"1.00"}]}}]}},
+		{"id":"p2","nm":"Salami","pc":"8.50","tc":"9.00"}]}}`)
+	var buffer bytes.Buffer
+	if err := ExportMenuJSONLines(&buffer, restaurant, MenuExportOptions{}); err != nil {
+		t.Fatalf(`Failed to export menu: %v`, err)
+	}
+	want := `{"allergens":["A","G"],"category":"Pizza","delivery_price":"8.00","description":"","pickup_price":"7.50","product":"Margherita",` +
+		`"side_dishes":[{"delivery_price":"1.00","name":"Käse","pickup_price":"0.50"}]}` + "\n" +
+		`{"allergens":[],"category":"Pizza","delivery_price":"9.00","description":"","pickup_price":"8.50","product":"Salami","side_dishes":[]}` + "\n"
+	if got := buffer.String(); got != want {
+		t.Errorf("ExportMenuJSONLines() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExportMenuMarkdown(t *testing.T) {
+	restaurant := testMenu(t, "R1",
+		`{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","ds":"Tomaten, Käse","pc":"7.50","tc":"8,00"}]}}`,
+		`{"id":"c2","nm":"Nudeln","ps":{"pr":[{"id":"p2","nm":"Ramen","pc":"11.00","tc":"11.50"}]}}`)
+	restaurant.Name = "Pizza Test"
+	var buffer bytes.Buffer
+	opts := MenuExportOptions{
+		Columns:     []MenuColumn{ColumnCategory, ColumnProduct, ColumnDescription, ColumnDeliveryPrice},
+		PriceFormat: PriceFormatForCountry(DE),
+	}
+	if err := ExportMenuMarkdown(&buffer, restaurant, opts); err != nil {
+		t.Fatalf(`Failed to export menu: %v`, err)
+	}
+	want := `# Pizza Test
+
+## Pizza
+
+| Product | Description | Delivery |
+| --- | --- | ---: |
+| Margherita | Tomaten, Käse | 8,00 € |
+
+## Nudeln
+
+| Product | Description | Delivery |
+| --- | --- | ---: |
+| Ramen |  | 11,50 € |
+`
+	if got := buffer.String(); got != want {
+		t.Errorf("ExportMenuMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMenuSideDishLabel(t *testing.T) {
+	price := func(amount Money) *Money { return &amount }
+	pickupOnly := MenuExportOptions{Columns: []MenuColumn{ColumnProduct, ColumnPickupPrice, ColumnSideDishes}}
+	deliveryOnly := MenuExportOptions{Columns: []MenuColumn{ColumnProduct, ColumnDeliveryPrice, ColumnSideDishes}}
+	both := MenuExportOptions{}
+	tests := []struct {
+		name     string
+		sideDish MenuSideDish
+		opts     MenuExportOptions
+		want     string
+	}{
+		{"pickup", MenuSideDish{"Käse", price(50), price(100)}, pickupOnly, "Käse (+0.50)"},
+		{"delivery", MenuSideDish{"Käse", price(50), price(100)}, deliveryOnly, "Käse (+1.00)"},
+		{"both equal", MenuSideDish{"Käse", price(100), price(100)}, both, "Käse (+1.00)"},
+		{"both different", MenuSideDish{"Käse", price(50), price(100)}, both, "Käse (+0.50 pickup, +1.00 delivery)"},
+		{"free", MenuSideDish{"Käse", price(0), price(0)}, both, "Käse"},
+		{"unparseable", MenuSideDish{"Käse", nil, price(100)}, pickupOnly, "Käse"},
+	}
+	for _, test := range tests {
+		if got := test.sideDish.label(test.opts); got != test.want {
+			t.Errorf(`%s: label() = %q, want %q`, test.name, got, test.want)
+		}
+	}
+}
+
+func TestExportMenuUnparseablePrice(t *testing.T) {
+	restaurant := testMenu(t, "R1", `{"nm":"Menu","ps":{"pr":[
+		{"id":"p1","nm":"Pizza","pc":"n/a","tc":"8.00"},
+		{"id":"p2","nm":"Salat","pc":"5.00","tc":"5.50"}]}}`)
+	var buffer bytes.Buffer
+	opts := MenuExportOptions{Columns: []MenuColumn{ColumnProduct, ColumnPickupPrice, ColumnDeliveryPrice}}
+	if err := ExportMenuCSV(&buffer, restaurant, opts); err != nil {
+		t.Fatalf(`Failed to export menu: %v`, err)
+	}
+	want := "product,pickup_price,delivery_price\nPizza,,8.00\nSalat,5.00,5.50\n"
+	if got := buffer.String(); got != want {
+		t.Errorf("ExportMenuCSV() = %q, want %q", got, want)
+	}
+}
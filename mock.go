@@ -0,0 +1,264 @@
+package takeawayapi
+
+import "sync"
+
+// MockCall is a single recorded call on a MockClient
+type MockCall struct {
+	Method string
+	Args   []any
+}
+
+// MockClient is an in-memory Client for testing code that depends on the API.
+// Every call is recorded. The response is taken from the matching ...Func field,
+// if it is nil the zero value and no error is returned.
+type MockClient struct {
+	GetCurrentTimeFunc            func(cc CountryCode, restaurantID string, orderingMode int) (CurrentTimeResponse, error)
+	GetRestaurantsFunc            func(postalCode string, cc CountryCode, latitude string, longitude string) (RestaurantsResponse, error)
+	GetCountriesDataFunc          func() (AvailableCountries, error)
+	GetRestaurantDataFunc         func(restaurantID string, postcode string, cc CountryCode, latitude string, longitude string, clientID string) (RestaurantData, error)
+	GetRestaurantCheckoutDataFunc func(restaurantID string, postcode string, cc CountryCode, latitude string, longitude string, clientID string) (RestaurantData, error)
+	GetRestaurantReviewsFunc      func(restaurantID string, page int) ([]Review, error)
+	GetGeoLocationDataFunc        func(latitude string, longitude string) (LocationData, error)
+	PlaceOrderFunc                func(order OrderRequest) (OrderResult, error)
+	GetOrderStatusFunc            func(orderID string) (OrderStatus, error)
+	LoginFunc                     func() (Session, error)
+	LogoutFunc                    func() error
+	GetOrderHistoryFunc           func(page int) (OrderHistoryPage, error)
+	GetAddressesFunc              func() ([]SavedAddress, error)
+	AddAddressFunc                func(address SavedAddress) (SavedAddress, error)
+	UpdateAddressFunc             func(address SavedAddress) (SavedAddress, error)
+	DeleteAddressFunc             func(addressID string) error
+	ValidateVoucherFunc           func(code string, restaurantID string) (Voucher, error)
+	GetStampCardsFunc             func() ([]StampCard, error)
+	GetFavoritesFunc              func() ([]Restaurant, error)
+	AddFavoriteFunc               func(restaurantID string) error
+	RemoveFavoriteFunc            func(restaurantID string) error
+
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+// Make sure MockClient always implements Client
+var _ Client = (*MockClient)(nil)
+
+func (m *MockClient) record(method string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+}
+
+// Calls returns all recorded calls in the order they were made
+func (m *MockClient) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	calls := make([]MockCall, len(m.calls))
+	copy(calls, m.calls)
+	return calls
+}
+
+// CallsTo returns the recorded calls of the given method
+func (m *MockClient) CallsTo(method string) []MockCall {
+	var calls []MockCall
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset removes all recorded calls
+func (m *MockClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// GetCurrentTime records the call and returns the result of GetCurrentTimeFunc
+func (m *MockClient) GetCurrentTime(cc CountryCode, RestaurantID string, OrderingMode int) (CurrentTimeResponse, error) {
+	m.record("GetCurrentTime", cc, RestaurantID, OrderingMode)
+	if m.GetCurrentTimeFunc == nil {
+		return CurrentTimeResponse{}, nil
+	}
+	return m.GetCurrentTimeFunc(cc, RestaurantID, OrderingMode)
+}
+
+// GetRestaurants records the call and returns the result of GetRestaurantsFunc
+func (m *MockClient) GetRestaurants(postalCode string, cc CountryCode, latitude string, longitude string) (RestaurantsResponse, error) {
+	m.record("GetRestaurants", postalCode, cc, latitude, longitude)
+	if m.GetRestaurantsFunc == nil {
+		return RestaurantsResponse{}, nil
+	}
+	return m.GetRestaurantsFunc(postalCode, cc, latitude, longitude)
+}
+
+// GetCountriesData records the call and returns the result of GetCountriesDataFunc
+func (m *MockClient) GetCountriesData() (AvailableCountries, error) {
+	m.record("GetCountriesData")
+	if m.GetCountriesDataFunc == nil {
+		return AvailableCountries{}, nil
+	}
+	return m.GetCountriesDataFunc()
+}
+
+// GetRestaurantData records the call and returns the result of GetRestaurantDataFunc
+func (m *MockClient) GetRestaurantData(restaurantId string, postcode string, cc CountryCode, latitude string, longitude string, clientID string) (RestaurantData, error) {
+	m.record("GetRestaurantData", restaurantId, postcode, cc, latitude, longitude, clientID)
+	if m.GetRestaurantDataFunc == nil {
+		return RestaurantData{}, nil
+	}
+	return m.GetRestaurantDataFunc(restaurantId, postcode, cc, latitude, longitude, clientID)
+}
+
+// GetRestaurantCheckoutData records the call and returns the result of GetRestaurantCheckoutDataFunc
+func (m *MockClient) GetRestaurantCheckoutData(restaurantId string, postcode string, cc CountryCode, latitude string, longitude string, clientID string) (RestaurantData, error) {
+	m.record("GetRestaurantCheckoutData", restaurantId, postcode, cc, latitude, longitude, clientID)
+	if m.GetRestaurantCheckoutDataFunc == nil {
+		return RestaurantData{}, nil
+	}
+	return m.GetRestaurantCheckoutDataFunc(restaurantId, postcode, cc, latitude, longitude, clientID)
+}
+
+// GetRestaurantReviews records the call and returns the result of GetRestaurantReviewsFunc
+func (m *MockClient) GetRestaurantReviews(restaurantID string, page int) ([]Review, error) {
+	m.record("GetRestaurantReviews", restaurantID, page)
+	if m.GetRestaurantReviewsFunc == nil {
+		return []Review{}, nil
+	}
+	return m.GetRestaurantReviewsFunc(restaurantID, page)
+}
+
+// GetGeoLocationData records the call and returns the result of GetGeoLocationDataFunc
+func (m *MockClient) GetGeoLocationData(latitude string, longitude string) (LocationData, error) {
+	m.record("GetGeoLocationData", latitude, longitude)
+	if m.GetGeoLocationDataFunc == nil {
+		return LocationData{}, nil
+	}
+	return m.GetGeoLocationDataFunc(latitude, longitude)
+}
+
+// PlaceOrder records the call and returns the result of PlaceOrderFunc
+func (m *MockClient) PlaceOrder(order OrderRequest) (OrderResult, error) {
+	m.record("PlaceOrder", order)
+	if m.PlaceOrderFunc == nil {
+		return OrderResult{}, nil
+	}
+	return m.PlaceOrderFunc(order)
+}
+
+// GetOrderStatus records the call and returns the result of GetOrderStatusFunc
+func (m *MockClient) GetOrderStatus(orderID string) (OrderStatus, error) {
+	m.record("GetOrderStatus", orderID)
+	if m.GetOrderStatusFunc == nil {
+		return OrderStatus{}, nil
+	}
+	return m.GetOrderStatusFunc(orderID)
+}
+
+// Login records the call and returns the result of LoginFunc
+func (m *MockClient) Login() (Session, error) {
+	m.record("Login")
+	if m.LoginFunc == nil {
+		return Session{}, nil
+	}
+	return m.LoginFunc()
+}
+
+// Logout records the call and returns the result of LogoutFunc
+func (m *MockClient) Logout() error {
+	m.record("Logout")
+	if m.LogoutFunc == nil {
+		return nil
+	}
+	return m.LogoutFunc()
+}
+
+// GetOrderHistory records the call and returns the result of GetOrderHistoryFunc
+func (m *MockClient) GetOrderHistory(page int) (OrderHistoryPage, error) {
+	m.record("GetOrderHistory", page)
+	if m.GetOrderHistoryFunc == nil {
+		return OrderHistoryPage{}, nil
+	}
+	return m.GetOrderHistoryFunc(page)
+}
+
+// GetAddresses records the call and returns the result of GetAddressesFunc
+func (m *MockClient) GetAddresses() ([]SavedAddress, error) {
+	m.record("GetAddresses")
+	if m.GetAddressesFunc == nil {
+		return []SavedAddress{}, nil
+	}
+	return m.GetAddressesFunc()
+}
+
+// AddAddress records the call and returns the result of AddAddressFunc
+func (m *MockClient) AddAddress(address SavedAddress) (SavedAddress, error) {
+	m.record("AddAddress", address)
+	if m.AddAddressFunc == nil {
+		return SavedAddress{}, nil
+	}
+	return m.AddAddressFunc(address)
+}
+
+// UpdateAddress records the call and returns the result of UpdateAddressFunc
+func (m *MockClient) UpdateAddress(address SavedAddress) (SavedAddress, error) {
+	m.record("UpdateAddress", address)
+	if m.UpdateAddressFunc == nil {
+		return SavedAddress{}, nil
+	}
+	return m.UpdateAddressFunc(address)
+}
+
+// DeleteAddress records the call and returns the result of DeleteAddressFunc
+func (m *MockClient) DeleteAddress(addressID string) error {
+	m.record("DeleteAddress", addressID)
+	if m.DeleteAddressFunc == nil {
+		return nil
+	}
+	return m.DeleteAddressFunc(addressID)
+}
+
+// ValidateVoucher records the call and returns the result of ValidateVoucherFunc
+func (m *MockClient) ValidateVoucher(code string, restaurantID string) (Voucher, error) {
+	m.record("ValidateVoucher", code, restaurantID)
+	if m.ValidateVoucherFunc == nil {
+		return Voucher{}, nil
+	}
+	return m.ValidateVoucherFunc(code, restaurantID)
+}
+
+// GetStampCards records the call and returns the result of GetStampCardsFunc
+func (m *MockClient) GetStampCards() ([]StampCard, error) {
+	m.record("GetStampCards")
+	if m.GetStampCardsFunc == nil {
+		return []StampCard{}, nil
+	}
+	return m.GetStampCardsFunc()
+}
+
+// GetFavorites records the call and returns the result of GetFavoritesFunc
+func (m *MockClient) GetFavorites() ([]Restaurant, error) {
+	m.record("GetFavorites")
+	if m.GetFavoritesFunc == nil {
+		return []Restaurant{}, nil
+	}
+	return m.GetFavoritesFunc()
+}
+
+// AddFavorite records the call and returns the result of AddFavoriteFunc
+func (m *MockClient) AddFavorite(restaurantID string) error {
+	m.record("AddFavorite", restaurantID)
+	if m.AddFavoriteFunc == nil {
+		return nil
+	}
+	return m.AddFavoriteFunc(restaurantID)
+}
+
+// RemoveFavorite records the call and returns the result of RemoveFavoriteFunc
+func (m *MockClient) RemoveFavorite(restaurantID string) error {
+	m.record("RemoveFavorite", restaurantID)
+	if m.RemoveFavoriteFunc == nil {
+		return nil
+	}
+	return m.RemoveFavoriteFunc(restaurantID)
+}
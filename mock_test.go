@@ -0,0 +1,44 @@
+package takeawayapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMockClient(t *testing.T) {
+	mock := &MockClient{
+		GetRestaurantDataFunc: func(restaurantID string, postcode string, cc CountryCode, latitude string, longitude string, clientID string) (RestaurantData, error) {
+			return RestaurantData{RestaurantID: restaurantID, Name: "Mock Pizza"}, nil
+		},
+		GetRestaurantReviewsFunc: func(restaurantID string, page int) ([]Review, error) {
+			return nil, errors.New("mock error")
+		},
+	}
+	var client Client = mock
+
+	restaurantData, err := client.GetRestaurantData("O3QQ11PN", "90461", DE, "", "", "")
+	if err != nil {
+		t.Fatalf(`GetRestaurantData errored with error: %v`, err)
+	}
+	if restaurantData.Name != "Mock Pizza" {
+		t.Fatalf(`GetRestaurantData returned wrong name: %v`, restaurantData.Name)
+	}
+	if _, err := client.GetRestaurantReviews("O3QQ11PN", 1); err == nil {
+		t.Fatalf(`GetRestaurantReviews did not return the programmed error`)
+	}
+	if _, err := client.GetCountriesData(); err != nil {
+		t.Fatalf(`GetCountriesData without func errored with error: %v`, err)
+	}
+
+	if len(mock.Calls()) != 3 {
+		t.Fatalf(`Expected 3 recorded calls, got %v`, len(mock.Calls()))
+	}
+	calls := mock.CallsTo("GetRestaurantData")
+	if len(calls) != 1 || calls[0].Args[0] != "O3QQ11PN" || calls[0].Args[2] != DE {
+		t.Fatalf(`GetRestaurantData call recorded wrong: %v`, calls)
+	}
+	mock.Reset()
+	if len(mock.Calls()) != 0 {
+		t.Fatalf(`Reset did not remove recorded calls`)
+	}
+}
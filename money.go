@@ -0,0 +1,77 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in cents. The API sends prices as strings like "7.50" or "7,50".
+type Money int64
+
+// ParseMoney parses a price as sent by the API. An empty string is zero.
+func ParseMoney(price string) (Money, error) {
+	price = strings.TrimSpace(price)
+	if price == "" {
+		return 0, nil
+	}
+	negative := strings.HasPrefix(price, "-")
+	price = strings.TrimPrefix(price, "-")
+	price = strings.ReplaceAll(price, ",", ".")
+
+	units, fraction, _ := strings.Cut(price, ".")
+	if units == "" {
+		units = "0"
+	}
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("invalid price %q: more than two decimals", price)
+	}
+	fraction = (fraction + "00")[:2]
+	unitsValue, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q: %w", price, err)
+	}
+	fractionValue, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q: %w", price, err)
+	}
+	money := Money(unitsValue*100 + fractionValue)
+	if negative {
+		money = -money
+	}
+	return money, nil
+}
+
+// String formats the amount with a decimal point like the API does, e.g. "7.50"
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, m/100, m%100)
+}
+
+// MarshalJSON encodes the amount as a string like the API does
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON decodes an amount sent as string or number
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var price string
+	if err := json.Unmarshal(data, &price); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("failed to unmarshal money: data=%s", string(data))
+		}
+		price = number.String()
+	}
+	parsed, err := ParseMoney(price)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,48 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := map[string]Money{
+		"7.50":  750,
+		"7,5":   750,
+		"12":    1200,
+		"0.05":  5,
+		"":      0,
+		"-1.20": -120,
+	}
+	for price, expected := range tests {
+		money, err := ParseMoney(price)
+		if err != nil {
+			t.Fatalf(`ParseMoney(%q) errored with error: %v`, price, err)
+		}
+		if money != expected {
+			t.Fatalf(`ParseMoney(%q) returned %v, expected %v`, price, money, expected)
+		}
+	}
+	for _, price := range []string{"abc", "1.234", "1.x"} {
+		if _, err := ParseMoney(price); err == nil {
+			t.Fatalf(`ParseMoney(%q) did not error`, price)
+		}
+	}
+	if Money(-120).String() != "-1.20" || Money(5).String() != "0.05" {
+		t.Fatalf(`Money.String formatted wrong: %v %v`, Money(-120), Money(5))
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var prices []Money
+	if err := json.Unmarshal([]byte(`["7.50", 8.5, null]`), &prices); err != nil {
+		t.Fatalf(`Unmarshal errored with error: %v`, err)
+	}
+	if prices[0] != 750 || prices[1] != 850 || prices[2] != 0 {
+		t.Fatalf(`Unmarshal returned wrong amounts: %v`, prices)
+	}
+	encoded, err := json.Marshal(Money(750))
+	if err != nil || string(encoded) != `"7.50"` {
+		t.Fatalf(`Marshal returned %s, %v`, encoded, err)
+	}
+}
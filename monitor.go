@@ -0,0 +1,443 @@
+package takeawayapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MonitorEventKind is the kind of a change detected by a Monitor
+type MonitorEventKind int
+
+const (
+	// EventRestaurantOpened means delivery or pickup became available, now or as pre-order
+	EventRestaurantOpened MonitorEventKind = iota
+	// EventRestaurantClosed means neither delivery nor pickup is available anymore
+	EventRestaurantClosed
+	// EventRestaurantAppeared means a restaurant is listed in a monitored area for the first time
+	EventRestaurantAppeared
+	// EventMenuChanged means the menu of a restaurant changed, see MonitorEvent.Diff
+	EventMenuChanged
+	// EventPriceChanged is emitted for every price change of a product or side dish, see MonitorEvent.Change
+	EventPriceChanged
+	// EventNewReview means a restaurant got a new review, see MonitorEvent.Review
+	EventNewReview
+)
+
+var monitorEventKindNames = map[MonitorEventKind]string{
+	EventRestaurantOpened:   "restaurant opened",
+	EventRestaurantClosed:   "restaurant closed",
+	EventRestaurantAppeared: "restaurant appeared",
+	EventMenuChanged:        "menu changed",
+	EventPriceChanged:       "price changed",
+	EventNewReview:          "new review",
+}
+
+func (k MonitorEventKind) String() string {
+	if name, ok := monitorEventKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("MonitorEventKind(%d)", int(k))
+}
+
+// MonitorArea is a location whose restaurants are monitored
+type MonitorArea struct {
+	Postcode    string
+	CountryCode CountryCode
+	Latitude    string
+	Longitude   string
+}
+
+func (a MonitorArea) key() string {
+	return fmt.Sprintf("%d|%s|%s|%s", a.CountryCode, a.Postcode, a.Latitude, a.Longitude)
+}
+
+// MonitorEvent is a change detected by a Monitor
+type MonitorEvent struct {
+	Kind           MonitorEventKind
+	Time           time.Time
+	RestaurantID   string
+	RestaurantName string
+	// Area is set for EventRestaurantAppeared
+	Area *MonitorArea
+	// Diff is set for EventMenuChanged
+	Diff *MenuDiff
+	// Change is set for EventPriceChanged
+	Change *MenuChange
+	// Review is set for EventNewReview
+	Review *Review
+}
+
+// MonitorState is what a Monitor has seen so far, events are only published when it changes
+type MonitorState struct {
+	// Open is whether each restaurant was available for delivery or pickup, keyed by source ("<area key>|<id>" or "restaurant|<id>")
+	// as areas and the restaurant data can report different states for the same restaurant
+	Open map[string]bool `json:"open"`
+	// Areas are the restaurant IDs listed in each area
+	Areas map[string][]string `json:"areas"`
+	// Menus are the last menus of each restaurant
+	Menus map[string]json.RawMessage `json:"menus"`
+	// Reviews are the keys of the latest reviews of each restaurant
+	Reviews map[string][]string `json:"reviews"`
+}
+
+// MonitorStateStore persists the state of a Monitor so no event is published twice across restarts.
+// Load returns an empty state if nothing is stored.
+type MonitorStateStore interface {
+	Load() (MonitorState, error)
+	Save(state MonitorState) error
+}
+
+// MonitorOptions configures a Monitor
+type MonitorOptions struct {
+	// Interval is the polling interval, defaults to 5 minutes
+	Interval time.Duration
+	// Areas are polled with GetRestaurants to detect new restaurants, openings and closings
+	Areas []MonitorArea
+	// RestaurantIDs are polled with GetRestaurantData to detect openings, closings and menu changes
+	RestaurantIDs []string
+	// Location is used to request the data of RestaurantIDs
+	Location MonitorArea
+	ClientID string
+	// Reviews enables polling the latest reviews of RestaurantIDs
+	Reviews bool
+	// StateStore persists the state, it is only kept in memory if nil
+	StateStore MonitorStateStore
+	// OnError is called for every failed request, polling continues
+	OnError func(error)
+}
+
+type monitorHandler struct {
+	handler func(MonitorEvent)
+	kinds   []MonitorEventKind
+}
+
+// Monitor periodically polls restaurants and publishes the detected changes to its handlers.
+// The first poll of an area or restaurant only records its state without publishing events.
+type Monitor struct {
+	client *TakeAwayClient
+	opts   MonitorOptions
+
+	mu       sync.Mutex
+	handlers []monitorHandler
+
+	// pollMu serializes polls, it guards state
+	pollMu sync.Mutex
+	state  *MonitorState
+}
+
+// NewMonitor creates a Monitor, call Run to start polling
+func (tac *TakeAwayClient) NewMonitor(opts MonitorOptions) *Monitor {
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Minute
+	}
+	return &Monitor{client: tac, opts: opts}
+}
+
+// Subscribe registers a handler for the given kinds of events, or for all events if no kind is given.
+// Handlers are called synchronously after each poll.
+func (m *Monitor) Subscribe(handler func(MonitorEvent), kinds ...MonitorEventKind) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers = append(m.handlers, monitorHandler{handler: handler, kinds: kinds})
+}
+
+func (m *Monitor) publish(events []MonitorEvent) {
+	m.mu.Lock()
+	handlers := slices.Clone(m.handlers)
+	m.mu.Unlock()
+	for _, event := range events {
+		for _, handler := range handlers {
+			if len(handler.kinds) == 0 || slices.Contains(handler.kinds, event.Kind) {
+				handler.handler(event)
+			}
+		}
+	}
+}
+
+// Run polls until ctx is done and returns ctx.Err()
+func (m *Monitor) Run(ctx context.Context) error {
+	for {
+		m.Poll(ctx)
+		timer := time.NewTimer(m.opts.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Poll checks all areas and restaurants once and publishes the changes.
+// Failed requests are reported to OnError and returned together, the other checks still run.
+func (m *Monitor) Poll(ctx context.Context) error {
+	m.pollMu.Lock()
+	defer m.pollMu.Unlock()
+	if m.state == nil {
+		state := MonitorState{}
+		if m.opts.StateStore != nil {
+			var err error
+			if state, err = m.opts.StateStore.Load(); err != nil {
+				return fmt.Errorf("error loading monitor state: %w", err)
+			}
+		}
+		state.init()
+		m.state = &state
+	}
+
+	client := m.client.WithContext(ctx).WithCacheMode(CacheBypass)
+	now := time.Now()
+	var events []MonitorEvent
+	var errs []error
+	fail := func(err error) {
+		if m.opts.OnError != nil {
+			m.opts.OnError(err)
+		}
+		errs = append(errs, err)
+	}
+
+	for _, area := range m.opts.Areas {
+		response, err := client.GetRestaurants(area.Postcode, area.CountryCode, area.Latitude, area.Longitude)
+		if err != nil {
+			fail(fmt.Errorf("error polling restaurants at %d %s: %w", area.CountryCode, area.Postcode, err))
+			continue
+		}
+		events = append(events, m.state.checkArea(area, response.Restaurants, now)...)
+	}
+
+	location := m.opts.Location
+	for _, restaurantID := range m.opts.RestaurantIDs {
+		restaurant, err := client.GetRestaurantData(restaurantID, location.Postcode, location.CountryCode, location.Latitude, location.Longitude, m.opts.ClientID)
+		if err != nil {
+			fail(fmt.Errorf("error polling restaurant %s: %w", restaurantID, err))
+			continue
+		}
+		events = append(events, m.state.checkOpen("restaurant|"+restaurantID, restaurantID, restaurant.Name, restaurant.Dm, now)...)
+		menuEvents, err := m.state.checkMenu(restaurant, now)
+		if err != nil {
+			fail(err)
+		}
+		events = append(events, menuEvents...)
+
+		if m.opts.Reviews {
+			reviews, err := client.GetRestaurantReviews(restaurantID, 1)
+			if err != nil {
+				fail(fmt.Errorf("error polling reviews of restaurant %s: %w", restaurantID, err))
+				continue
+			}
+			events = append(events, m.state.checkReviews(restaurantID, restaurant.Name, reviews, now)...)
+		}
+	}
+
+	// The state is saved before publishing, so a crash in a handler doesn't publish the events again
+	if m.opts.StateStore != nil {
+		if err := m.opts.StateStore.Save(*m.state); err != nil {
+			fail(fmt.Errorf("error saving monitor state: %w", err))
+		}
+	}
+	m.publish(events)
+	return errors.Join(errs...)
+}
+
+func (s *MonitorState) init() {
+	if s.Open == nil {
+		s.Open = map[string]bool{}
+	}
+	if s.Areas == nil {
+		s.Areas = map[string][]string{}
+	}
+	if s.Menus == nil {
+		s.Menus = map[string]json.RawMessage{}
+	}
+	if s.Reviews == nil {
+		s.Reviews = map[string][]string{}
+	}
+}
+
+// checkOpen records whether the restaurant is open as seen by the source key and returns an event if that changed
+func (s *MonitorState) checkOpen(key, restaurantID, name string, modes DeliveryModes, now time.Time) []MonitorEvent {
+	open := modes.SupportsDelivery() || modes.SupportsPickup()
+	wasOpen, known := s.Open[key]
+	s.Open[key] = open
+	if !known || wasOpen == open {
+		return nil
+	}
+	kind := EventRestaurantClosed
+	if open {
+		kind = EventRestaurantOpened
+	}
+	return []MonitorEvent{{Kind: kind, Time: now, RestaurantID: restaurantID, RestaurantName: name}}
+}
+
+// checkArea records the restaurants listed in the area and returns events for new ones and changed opening states
+func (s *MonitorState) checkArea(area MonitorArea, restaurants []Restaurant, now time.Time) []MonitorEvent {
+	var events []MonitorEvent
+	known, polled := s.Areas[area.key()]
+	ids := make([]string, 0, len(restaurants))
+	for _, restaurant := range restaurants {
+		ids = append(ids, restaurant.ID)
+		if polled && !slices.Contains(known, restaurant.ID) {
+			events = append(events, MonitorEvent{Kind: EventRestaurantAppeared, Time: now, RestaurantID: restaurant.ID, RestaurantName: restaurant.Name, Area: &area})
+		}
+		for _, event := range s.checkOpen(area.key()+"|"+restaurant.ID, restaurant.ID, restaurant.Name, restaurant.Dm, now) {
+			event.Area = &area
+			events = append(events, event)
+		}
+	}
+	s.Areas[area.key()] = ids
+	return events
+}
+
+// checkMenu records the menu of the restaurant and returns events for its changes
+func (s *MonitorState) checkMenu(restaurant RestaurantData, now time.Time) ([]MonitorEvent, error) {
+	snapshot, err := encodeMenuSnapshot(restaurant)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding menu of restaurant %s: %w", restaurant.RestaurantID, err)
+	}
+	previous, known := s.Menus[restaurant.RestaurantID]
+	s.Menus[restaurant.RestaurantID] = snapshot
+	if !known {
+		return nil, nil
+	}
+	old, err := decodeMenuSnapshot(previous)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding last menu of restaurant %s: %w", restaurant.RestaurantID, err)
+	}
+	diff := DiffMenus(old, restaurant)
+	if diff.Empty() {
+		return nil, nil
+	}
+	events := []MonitorEvent{{Kind: EventMenuChanged, Time: now, RestaurantID: restaurant.RestaurantID, RestaurantName: restaurant.Name, Diff: &diff}}
+	for i, change := range diff.Changes {
+		if change.Kind == MenuProductPriceChanged || change.Kind == MenuSideDishPriceChanged {
+			events = append(events, MonitorEvent{Kind: EventPriceChanged, Time: now, RestaurantID: restaurant.RestaurantID, RestaurantName: restaurant.Name, Change: &diff.Changes[i]})
+		}
+	}
+	return events, nil
+}
+
+// reviewKey identifies a review, the API sends no review IDs
+func reviewKey(review Review) string {
+	return review.TimeStr + "|" + review.Name + "|" + review.Remark
+}
+
+// checkReviews records the latest reviews of the restaurant and returns events for new ones
+func (s *MonitorState) checkReviews(restaurantID, name string, reviews []Review, now time.Time) []MonitorEvent {
+	var events []MonitorEvent
+	known, polled := s.Reviews[restaurantID]
+	keys := make([]string, 0, len(reviews))
+	for i, review := range reviews {
+		key := reviewKey(review)
+		keys = append(keys, key)
+		if polled && !slices.Contains(known, key) {
+			events = append(events, MonitorEvent{Kind: EventNewReview, Time: now, RestaurantID: restaurantID, RestaurantName: name, Review: &reviews[i]})
+		}
+	}
+	s.Reviews[restaurantID] = keys
+	return events
+}
+
+// menuSnapshot is the part of RestaurantData compared by DiffMenus, encoded like the API does
+type menuSnapshot struct {
+	RestaurantID string `json:"ri"`
+	Name         string `json:"nm"`
+	Menu         struct {
+		Categories struct {
+			Categories []menuSnapshotCategory `json:"ct"`
+		} `json:"cs"`
+	} `json:"mc"`
+}
+
+type menuSnapshotCategory struct {
+	ID       string `json:"id"`
+	Name     string `json:"nm"`
+	Products struct {
+		Products []menuSnapshotProduct `json:"pr"`
+	} `json:"ps"`
+}
+
+type menuSnapshotProduct struct {
+	ID           string `json:"id"`
+	Name         string `json:"nm"`
+	Description  string `json:"ds,omitempty"`
+	PickupCost   string `json:"pc"`
+	DeliveryCost string `json:"tc"`
+	SideItems    struct {
+		SideDishes []SideDish `json:"sd"`
+	} `json:"ss"`
+}
+
+// encodeMenuSnapshot encodes the menu of the restaurant so it can be decoded as RestaurantData again
+func encodeMenuSnapshot(restaurant RestaurantData) (json.RawMessage, error) {
+	var snapshot menuSnapshot
+	snapshot.RestaurantID = restaurant.RestaurantID
+	snapshot.Name = restaurant.Name
+	for _, category := range restaurant.Menu.CategorieStruct.Categories {
+		snapshotCategory := menuSnapshotCategory{ID: category.ID, Name: category.Name}
+		for _, product := range category.ProductStruct.Products {
+			snapshotProduct := menuSnapshotProduct{
+				ID:           product.ID,
+				Name:         product.Name,
+				Description:  product.Description,
+				PickupCost:   product.PickupCost,
+				DeliveryCost: product.DeliveryCost,
+			}
+			snapshotProduct.SideItems.SideDishes = product.SideItems.SideDishes
+			snapshotCategory.Products.Products = append(snapshotCategory.Products.Products, snapshotProduct)
+		}
+		snapshot.Menu.Categories.Categories = append(snapshot.Menu.Categories.Categories, snapshotCategory)
+	}
+	return json.Marshal(snapshot)
+}
+
+// decodeMenuSnapshot decodes a menu encoded by encodeMenuSnapshot
+func decodeMenuSnapshot(snapshot json.RawMessage) (RestaurantData, error) {
+	var restaurant RestaurantData
+	err := json.Unmarshal(snapshot, &restaurant)
+	return restaurant, err
+}
+
+// FileMonitorStateStore keeps the state of a Monitor in a JSON file
+type FileMonitorStateStore struct {
+	Path string
+}
+
+// NewFileMonitorStateStore creates a FileMonitorStateStore writing to path
+func NewFileMonitorStateStore(path string) *FileMonitorStateStore {
+	return &FileMonitorStateStore{Path: path}
+}
+
+// Load reads the state from the file, a missing file is an empty state
+func (s *FileMonitorStateStore) Load() (MonitorState, error) {
+	var state MonitorState
+	content, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(content, &state); err != nil {
+		return MonitorState{}, fmt.Errorf("error decoding monitor state file: %w", err)
+	}
+	return state, nil
+}
+
+// Save writes the state to the file
+func (s *FileMonitorStateStore) Save(state MonitorState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o755); err != nil {
+		return err
+	}
+	return writeFileAtomic(s.Path, content)
+}
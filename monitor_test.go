@@ -0,0 +1,200 @@
+package takeawayapi
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testReviewsResponse = `{"rr":{"rv":[{"nm":"Max","ti":"2024-01-01 12:00:00","rm":"Lecker"}]}}`
+
+// monitorRestaurantsResponse returns a restaurant list response with the given restaurants
+func monitorRestaurantsResponse(restaurants ...string) string {
+	return `{"rs":{"ct":"2024-01-01 12:00:00","rt":[` + strings.Join(restaurants, ",") + `]}}`
+}
+
+// monitorMenuResponse returns the restaurant data of R1 with a single product of the given delivery price
+func monitorMenuResponse(price string) string {
+	return `{"rd":{"nm":"Pizza Test","ri":"R1","ct":"2024-01-01 12:00:00","mc":{"cs":{"ct":[
+		{"id":"c1","nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"7.50","tc":"` + price + `"}]}}]}}}}`
+}
+
+// describeMonitorEvent summarizes the fields of the event that are set for its kind
+func describeMonitorEvent(event MonitorEvent) string {
+	description := event.Kind.String() + " " + event.RestaurantID
+	if event.Area != nil {
+		description += " in " + event.Area.Postcode
+	}
+	if event.Change != nil {
+		description += fmt.Sprintf(" %s %v", event.Change.ProductID, event.Change.NewPrice)
+	}
+	if event.Review != nil {
+		description += " by " + event.Review.Name
+	}
+	return description
+}
+
+func TestMonitor(t *testing.T) {
+	const (
+		openA   = `{"id":"A","nm":"Pizza A","dm":{"dl":{"op":1}}}`
+		closedA = `{"id":"A","nm":"Pizza A","dm":{"dl":{"op":0}}}`
+		openE   = `{"id":"E","nm":"Döner E","dm":{"dl":{"op":1}}}`
+		reviews = `{"rr":{"rv":[{"nm":"Erika","ti":"2024-01-02 12:00:00","rm":"Zu salzig"},{"nm":"Max","ti":"2024-01-01 12:00:00","rm":"Lecker"}]}}`
+	)
+	tac, ts := newTestClient(t, map[string]string{})
+	opts := MonitorOptions{
+		Areas:         []MonitorArea{{Postcode: "90461", CountryCode: DE}},
+		RestaurantIDs: []string{"R1"},
+		Location:      MonitorArea{Postcode: "90461", CountryCode: DE},
+		Reviews:       true,
+		StateStore:    NewFileMonitorStateStore(filepath.Join(t.TempDir(), "state", "monitor.json")),
+	}
+	monitor := tac.NewMonitor(opts)
+	var events []string
+	monitor.Subscribe(func(event MonitorEvent) { events = append(events, describeMonitorEvent(event)) })
+
+	steps := []struct {
+		name        string
+		restaurants string
+		menu        string
+		reviews     string
+		want        []string
+	}{
+		{
+			name:        "first poll",
+			restaurants: monitorRestaurantsResponse(openA),
+			menu:        monitorMenuResponse("8.00"),
+			reviews:     testReviewsResponse,
+		},
+		{
+			name:        "restaurant closes",
+			restaurants: monitorRestaurantsResponse(closedA),
+			menu:        monitorMenuResponse("8.00"),
+			reviews:     testReviewsResponse,
+			want:        []string{"restaurant closed A in 90461"},
+		},
+		{
+			name:        "restaurant appears",
+			restaurants: monitorRestaurantsResponse(closedA, openE),
+			menu:        monitorMenuResponse("8.00"),
+			reviews:     testReviewsResponse,
+			want:        []string{"restaurant appeared E in 90461"},
+		},
+		{
+			name:        "price changes",
+			restaurants: monitorRestaurantsResponse(closedA, openE),
+			menu:        monitorMenuResponse("8.50"),
+			reviews:     testReviewsResponse,
+			want:        []string{"menu changed R1", "price changed R1 p1 8.50"},
+		},
+		{
+			name:        "new review",
+			restaurants: monitorRestaurantsResponse(closedA, openE),
+			menu:        monitorMenuResponse("8.50"),
+			reviews:     reviews,
+			want:        []string{"new review R1 by Erika"},
+		},
+		{
+			name:        "unchanged",
+			restaurants: monitorRestaurantsResponse(closedA, openE),
+			menu:        monitorMenuResponse("8.50"),
+			reviews:     reviews,
+		},
+	}
+	ctx := context.Background()
+	for _, step := range steps {
+		ts.SetResponse("getrestaurants", step.restaurants)
+		ts.SetResponse("getrestaurantdata", step.menu)
+		ts.SetResponse("restaurantreviews", step.reviews)
+		events = nil
+		if err := monitor.Poll(ctx); err != nil {
+			t.Fatalf(`%s: Failed to poll: %v`, step.name, err)
+		}
+		if !slices.Equal(events, step.want) {
+			t.Errorf(`%s: events = %q, want %q`, step.name, events, step.want)
+		}
+	}
+
+	// A new monitor restores the state and sees no changes
+	events = nil
+	restarted := tac.NewMonitor(opts)
+	restarted.Subscribe(func(event MonitorEvent) { events = append(events, describeMonitorEvent(event)) })
+	if err := restarted.Poll(ctx); err != nil {
+		t.Fatalf(`Failed to poll: %v`, err)
+	}
+	if len(events) != 0 {
+		t.Errorf(`Expected no events after restoring the state, got %q`, events)
+	}
+}
+
+func TestMonitorSubscribeKinds(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{"getrestaurantdata": monitorMenuResponse("8.00")})
+	monitor := tac.NewMonitor(MonitorOptions{RestaurantIDs: []string{"R1"}})
+	var prices []string
+	monitor.Subscribe(func(event MonitorEvent) { prices = append(prices, describeMonitorEvent(event)) }, EventPriceChanged)
+	if err := monitor.Poll(context.Background()); err != nil {
+		t.Fatalf(`Failed to poll: %v`, err)
+	}
+	ts.SetResponse("getrestaurantdata", monitorMenuResponse("8.50"))
+	if err := monitor.Poll(context.Background()); err != nil {
+		t.Fatalf(`Failed to poll: %v`, err)
+	}
+	if want := []string{"price changed R1 p1 8.50"}; !slices.Equal(prices, want) {
+		t.Errorf(`events = %q, want %q`, prices, want)
+	}
+}
+
+func TestMonitorErrors(t *testing.T) {
+	tac, _ := newTestClient(t, map[string]string{"getrestaurantdata": monitorMenuResponse("8.00")})
+	var errs []error
+	monitor := tac.NewMonitor(MonitorOptions{
+		Areas:         []MonitorArea{{Postcode: "90461", CountryCode: DE}},
+		RestaurantIDs: []string{"R1"},
+		OnError:       func(err error) { errs = append(errs, err) },
+	})
+	err := monitor.Poll(context.Background())
+	if err == nil || len(errs) != 1 || !strings.Contains(errs[0].Error(), "90461") {
+		t.Errorf(`Expected the failing area to be reported, got %v and %v`, err, errs)
+	}
+	if _, ok := monitor.state.Menus["R1"]; !ok {
+		t.Errorf(`Expected the restaurant to be polled despite the failing area`)
+	}
+}
+
+func TestMonitorOpenPerSource(t *testing.T) {
+	var state MonitorState
+	state.init()
+	now := time.Now()
+	open := DeliveryModes{Delivery: DeliveryMode{State: OperatingOpen}}
+	closed := DeliveryModes{Delivery: DeliveryMode{State: OperatingClosed}}
+	north := MonitorArea{Postcode: "90461", CountryCode: DE}
+	south := MonitorArea{Postcode: "90459", CountryCode: DE}
+
+	// The same restaurant is open in one area and closed in the other area and in its restaurant data
+	poll := func(southModes DeliveryModes) []MonitorEvent {
+		events := state.checkArea(north, []Restaurant{{ID: "A", Dm: open}}, now)
+		events = append(events, state.checkArea(south, []Restaurant{{ID: "A", Dm: southModes}}, now)...)
+		return append(events, state.checkOpen("restaurant|A", "A", "", closed, now)...)
+	}
+	for range 3 {
+		if events := poll(closed); len(events) != 0 {
+			t.Fatalf(`Expected no events while nothing changes, got %+v`, events)
+		}
+	}
+	events := poll(open)
+	if len(events) != 1 || events[0].Kind != EventRestaurantOpened || events[0].Area.Postcode != "90459" {
+		t.Fatalf(`Expected A to open in 90459 only, got %+v`, events)
+	}
+	// Pre-orders keep the restaurant available
+	preOrder := DeliveryModes{Delivery: DeliveryMode{State: OperatingPreOrder}}
+	if events := poll(preOrder); len(events) != 0 {
+		t.Fatalf(`Expected no events switching to pre-order, got %+v`, events)
+	}
+	if events := poll(closed); len(events) != 1 || events[0].Kind != EventRestaurantClosed {
+		t.Fatalf(`Expected A to close in 90459, got %+v`, events)
+	}
+}
@@ -0,0 +1,165 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// asSoonAsPossible is sent as requested time if the order should be delivered as soon as possible
+const asSoonAsPossible = "ASAP"
+
+// Customer is the contact of the person placing an order
+type Customer struct {
+	Name        string
+	Email       string
+	Phone       string
+	CompanyName string
+}
+
+// OrderRequest contains everything needed to place an order
+type OrderRequest struct {
+	Basket ValidatedBasket
+	// Address is the delivery address, it is ignored for pickup orders
+	Address Address
+	// RequestedTime is the requested delivery or pickup time, the zero time means as soon as possible
+	RequestedTime time.Time
+	// PaymentMethod must be offered by the restaurant, it should be one of RestaurantData.UsablePaymentMethods.
+	// The zero value is PaymentCash, it is only accepted if the restaurant offers cash payment.
+	PaymentMethod PaymentMethod
+	Customer      Customer
+	Remark        string
+	// DryRun validates and signs the request without sending it
+	DryRun bool
+}
+
+type placeOrderResponse struct {
+	OrderConfirmation OrderConfirmation `json:"oc"`
+}
+
+// OrderConfirmation is returned by the API for a placed order
+type OrderConfirmation struct {
+	OrderID                  string `json:"oi"`
+	EstimatedDeliveryTimeStr string `json:"dt"`
+	EstimatedDeliveryTime    time.Time
+	// PaymentURL is set for online payment methods, the order is only processed after the payment is completed there
+	PaymentURL string `json:"pu"`
+}
+
+// OrderResult is the outcome of PlaceOrder
+type OrderResult struct {
+	OrderID      string
+	Confirmation OrderConfirmation
+	// DryRun is true if the order was not sent, Request then contains the signed request parameters
+	DryRun  bool
+	Request url.Values
+}
+
+type orderLine struct {
+	ProductID   string   `json:"id"`
+	Quantity    int      `json:"qt"`
+	SideDishIDs []string `json:"sd,omitempty"`
+	Remark      string   `json:"rm,omitempty"`
+}
+
+// orderParams returns the parameters of the placeorder function in the order they are signed
+func (order OrderRequest) orderParams() ([]interface{}, error) {
+	basket := order.Basket.Basket
+	lines := make([]orderLine, 0, len(basket.Items))
+	for _, item := range basket.Items {
+		lines = append(lines, orderLine{ProductID: item.ProductID, Quantity: item.Quantity, SideDishIDs: item.SideDishIDs, Remark: item.Remark})
+	}
+	products, err := json.Marshal(lines)
+	if err != nil {
+		return nil, err
+	}
+	requestedTime := asSoonAsPossible
+	if !order.RequestedTime.IsZero() {
+		requestedTime = order.RequestedTime.Format(takeAwayTimeFormat)
+	}
+	address := order.Address
+	if basket.Mode == OrderModePickup {
+		address = Address{}
+	}
+	voucherCode := ""
+	if basket.Voucher != nil {
+		voucherCode = basket.Voucher.Code
+	}
+	return []interface{}{
+		basket.RestaurantID,
+		int(basket.Mode),
+		string(products),
+		requestedTime,
+		int(order.PaymentMethod),
+		order.Customer.Name,
+		order.Customer.Email,
+		order.Customer.Phone,
+		order.Customer.CompanyName,
+		address.Street,
+		address.Housenumber,
+		address.Postcode,
+		address.City,
+		order.Remark,
+		order.Basket.Totals.Total.String(),
+		voucherCode,
+	}, nil
+}
+
+// Validate checks that the order is complete
+func (order OrderRequest) Validate() error {
+	if !order.Basket.validated {
+		return errors.New("basket was not validated, use Basket.Validate")
+	}
+	if !slices.Contains(order.Basket.paymentMethods, order.PaymentMethod) {
+		return fmt.Errorf("missing payment method: %v is not offered by the restaurant", order.PaymentMethod)
+	}
+	if order.Customer.Name == "" || order.Customer.Email == "" || order.Customer.Phone == "" {
+		return errors.New("customer name, email and phone are required")
+	}
+	if order.Basket.Basket.Mode == OrderModeDelivery {
+		if order.Address.Street == "" || order.Address.Housenumber == "" || order.Address.Postcode == "" || order.Address.City == "" {
+			return errors.New("delivery address is incomplete")
+		}
+		if order.Address.Postcode != order.Basket.Basket.Postcode {
+			return fmt.Errorf("delivery postcode %s does not match basket postcode %s", order.Address.Postcode, order.Basket.Basket.Postcode)
+		}
+	}
+	if !order.RequestedTime.IsZero() && order.RequestedTime.Before(time.Now()) {
+		return errors.New("requested time is in the past")
+	}
+	return nil
+}
+
+// PlaceOrder submits the order and returns its ID and confirmation data.
+// With DryRun set the order is validated and signed but not sent.
+func (tac *TakeAwayClient) PlaceOrder(order OrderRequest) (OrderResult, error) {
+	function := "placeorder"
+	if err := order.Validate(); err != nil {
+		return OrderResult{}, fmt.Errorf("invalid order: %w", err)
+	}
+	params, err := order.orderParams()
+	if err != nil {
+		return OrderResult{}, fmt.Errorf("error encoding order: %w", err)
+	}
+	if order.DryRun {
+		data, _ := tac.signRequest(function, params...)
+		return OrderResult{DryRun: true, Request: data}, nil
+	}
+
+	var placeOrderResponse placeOrderResponse
+	err = tac.sendRequest(function, &placeOrderResponse, params...)
+	if err != nil {
+		return OrderResult{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	confirmation := placeOrderResponse.OrderConfirmation
+	if confirmation.EstimatedDeliveryTimeStr != "" {
+		confirmation.EstimatedDeliveryTime, err = ParseTakeAwayTime(confirmation.EstimatedDeliveryTimeStr)
+		if err != nil {
+			return OrderResult{}, fmt.Errorf("error parsing estimated delivery time: %w", err)
+		}
+	}
+	return OrderResult{OrderID: confirmation.OrderID, Confirmation: confirmation}, nil
+}
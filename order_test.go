@@ -0,0 +1,151 @@
+package takeawayapi
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBasketValidate(t *testing.T) {
+	restaurant := testRestaurantData(t)
+
+	basket := Basket{RestaurantID: "O3QQ11PN", Mode: OrderModeDelivery, Postcode: "90461"}
+	basket.Add("p1", 1, "s1")
+	basket.Add("p3", 1)
+	validated, err := basket.Validate(restaurant)
+	if err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+	// 8.00 + 1.00 side dish + 11.50, above 20.00 delivery is free
+	if validated.Totals.Subtotal != 2050 || validated.Totals.DeliveryCosts != 0 || validated.Totals.Total != 2050 {
+		t.Fatalf(`Validate returned wrong totals: %+v`, validated.Totals)
+	}
+
+	basket = Basket{RestaurantID: "O3QQ11PN", Mode: OrderModePickup}
+	basket.Add("p2", 2)
+	validated, err = basket.Validate(restaurant)
+	if err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+	if validated.Totals.Total != 1700 {
+		t.Fatalf(`Validate returned wrong pickup total: %+v`, validated.Totals)
+	}
+
+	invalid := map[string]Basket{
+		"below minimum":    {RestaurantID: "O3QQ11PN", Mode: OrderModeDelivery, Postcode: "90461", Items: []BasketItem{{ProductID: "p1", Quantity: 1}}},
+		"unknown postcode": {RestaurantID: "O3QQ11PN", Mode: OrderModeDelivery, Postcode: "10115", Items: []BasketItem{{ProductID: "p3", Quantity: 2}}},
+		"unknown product":  {RestaurantID: "O3QQ11PN", Mode: OrderModePickup, Items: []BasketItem{{ProductID: "xx", Quantity: 1}}},
+		"unknown side":     {RestaurantID: "O3QQ11PN", Mode: OrderModePickup, Items: []BasketItem{{ProductID: "p2", Quantity: 1, SideDishIDs: []string{"s1"}}}},
+		"wrong restaurant": {RestaurantID: "OTHER", Mode: OrderModePickup, Items: []BasketItem{{ProductID: "p2", Quantity: 1}}},
+		"empty":            {RestaurantID: "O3QQ11PN", Mode: OrderModePickup},
+		"zero quantity":    {RestaurantID: "O3QQ11PN", Mode: OrderModePickup, Items: []BasketItem{{ProductID: "p2"}}},
+	}
+	for name, basket := range invalid {
+		if _, err := basket.Validate(restaurant); err == nil {
+			t.Fatalf(`Validate accepted invalid basket: %v`, name)
+		}
+	}
+}
+
+func TestBasketTotalsDeliveryTiers(t *testing.T) {
+	tests := map[string]struct {
+		tiers   []DeliveryCost
+		want    Money
+		wantErr bool
+	}{
+		"matching tier":     {[]DeliveryCost{{Fr: "0", To: "10.00", Ct: "2.00"}, {Fr: "10.00", To: "0", Ct: "1.00"}}, 100, false},
+		"gap between tiers": {[]DeliveryCost{{Fr: "0", To: "10.00", Ct: "2.00"}, {Fr: "20.00", To: "0", Ct: "0"}}, 0, true},
+		"first tier above":  {[]DeliveryCost{{Fr: "20.00", To: "0", Ct: "0"}}, 0, true},
+		"no tiers":          {nil, 0, true},
+	}
+
+	for name, test := range tests {
+		restaurant := testRestaurantData(t)
+		restaurant.DeliveryData.Da[0].Costs = test.tiers
+		// Margherita for 8.00 and Salami for 9.00
+		basket := Basket{RestaurantID: "O3QQ11PN", Mode: OrderModeDelivery, Postcode: "90461", Items: []BasketItem{{ProductID: "p1", Quantity: 1}, {ProductID: "p2", Quantity: 1}}}
+		totals, err := basket.Totals(restaurant)
+		if (err != nil) != test.wantErr || totals.DeliveryCosts != test.want {
+			t.Errorf(`%s: Totals() = %+v, %v, want delivery costs %v (error %v)`, name, totals, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func testOrderRequest(t *testing.T) OrderRequest {
+	t.Helper()
+	basket := Basket{RestaurantID: "O3QQ11PN", Mode: OrderModeDelivery, Postcode: "90461"}
+	basket.Add("p3", 2)
+	validated, err := basket.Validate(testRestaurantData(t))
+	if err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+	return OrderRequest{
+		Basket:        validated,
+		Address:       Address{Street: "Hauptstraße", Housenumber: "1", Postcode: "90461", City: "Nürnberg"},
+		PaymentMethod: PaymentCash,
+		Customer:      Customer{Name: "Max Mustermann", Email: "max@example.com", Phone: "0911123456"},
+	}
+}
+
+func TestPlaceOrder(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{"placeorder": `{"oc":{"oi":"ORDER1","dt":"2024-01-01 12:45:00"}}`})
+	order := testOrderRequest(t)
+
+	order.DryRun = true
+	result, err := tac.PlaceOrder(order)
+	if err != nil {
+		t.Fatalf(`PlaceOrder dry run errored with error: %v`, err)
+	}
+	if !result.DryRun || result.Request.Get("var1") != "placeorder" || result.Request.Get("var0") == "" {
+		t.Fatalf(`PlaceOrder dry run returned wrong request: %v`, result.Request)
+	}
+	if !strings.Contains(result.Request.Get("var4"), `"id":"p3"`) {
+		t.Fatalf(`PlaceOrder dry run did not encode the products: %v`, result.Request.Get("var4"))
+	}
+	if ts.Hits("placeorder") != 0 {
+		t.Fatalf(`PlaceOrder dry run sent the order`)
+	}
+
+	order.DryRun = false
+	result, err = tac.PlaceOrder(order)
+	if err != nil {
+		t.Fatalf(`PlaceOrder errored with error: %v`, err)
+	}
+	if result.OrderID != "ORDER1" || result.Confirmation.EstimatedDeliveryTime.IsZero() {
+		t.Fatalf(`PlaceOrder returned wrong result: %+v`, result)
+	}
+
+	order.Basket = ValidatedBasket{}
+	if _, err := tac.PlaceOrder(order); err == nil {
+		t.Fatalf(`PlaceOrder accepted an unvalidated basket`)
+	}
+}
+
+func TestOrderRequestPaymentMethod(t *testing.T) {
+	order := testOrderRequest(t)
+	if err := order.Validate(); err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+	order.PaymentMethod = PaymentPayPal
+	if err := order.Validate(); err == nil {
+		t.Fatalf(`Validate accepted a payment method the restaurant doesn't offer`)
+	}
+
+	// Without cash payment the zero value must not place a cash order
+	restaurant := testRestaurantData(t)
+	restaurant.Pm.Me[0].Mi = strconv.Itoa(int(PaymentPayPal))
+	basket := Basket{RestaurantID: "O3QQ11PN", Mode: OrderModePickup}
+	basket.Add("p2", 1)
+	validated, err := basket.Validate(restaurant)
+	if err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+	order = OrderRequest{Basket: validated, Customer: order.Customer}
+	if err := order.Validate(); err == nil || !strings.Contains(err.Error(), "missing payment method") {
+		t.Fatalf(`Validate accepted an order without payment method: %v`, err)
+	}
+	order.PaymentMethod = PaymentPayPal
+	if err := order.Validate(); err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+}
@@ -0,0 +1,174 @@
+package takeawayapi
+
+import (
+	"fmt"
+	"iter"
+	"time"
+)
+
+type orderHistoryResponse struct {
+	OrderHistory OrderHistoryPage `json:"oh"`
+}
+
+// OrderHistoryPage is a single page of the order history of the logged in user
+type OrderHistoryPage struct {
+	Orders     []Order `json:"or"`
+	Pagination struct {
+		CurrentPage int `json:"cp"`
+		TotalPages  int `json:"tp"`
+	} `json:"pg"`
+}
+
+// Order is a past order of the logged in user
+type Order struct {
+	OrderID        string     `json:"oi"`
+	RestaurantID   string     `json:"ri"`
+	RestaurantName string     `json:"rn"`
+	Mode           OrderMode  `json:"md"`
+	State          OrderState `json:"st"`
+	PlacedAtStr    string     `json:"ti"`
+	PlacedAt       time.Time
+	Lines          []OrderLine   `json:"ol"`
+	Totals         OrderTotals   `json:"tt"`
+	Address        Address       `json:"ad"`
+	PaymentMethod  PaymentMethod `json:"pm"`
+}
+
+// OrderLine is a product of a past order
+type OrderLine struct {
+	ProductID   string   `json:"id"`
+	Name        string   `json:"nm"`
+	Quantity    int      `json:"qt"`
+	SideDishIDs []string `json:"sd"`
+	// UnitPrice is the price of one product including its side dishes
+	UnitPrice Money  `json:"up"`
+	Total     Money  `json:"tp"`
+	Remark    string `json:"rm"`
+}
+
+// OrderTotals are the amounts charged for a past order
+type OrderTotals struct {
+	Subtotal      Money `json:"st"`
+	DeliveryCosts Money `json:"dc"`
+	Discount      Money `json:"ds"`
+	Total         Money `json:"tt"`
+}
+
+// GetOrderHistory returns a page of the order history of the logged in user, pages start at 1
+func (tac *TakeAwayClient) GetOrderHistory(page int) (OrderHistoryPage, error) {
+	function := "getorderhistory"
+	var orderHistoryResponse orderHistoryResponse
+	err := tac.sendRequest(function, &orderHistoryResponse, page)
+	if err != nil {
+		return OrderHistoryPage{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	for i := range orderHistoryResponse.OrderHistory.Orders {
+		order := &orderHistoryResponse.OrderHistory.Orders[i]
+		order.PlacedAt, err = ParseTakeAwayTime(order.PlacedAtStr)
+		if err != nil {
+			return OrderHistoryPage{}, fmt.Errorf("error parsing time of order %s: %w", order.OrderID, err)
+		}
+	}
+	return orderHistoryResponse.OrderHistory, nil
+}
+
+// OrderHistory iterates over all past orders of the logged in user, fetching pages as needed.
+// Iteration stops after the first error.
+//
+//	for order, err := range tac.OrderHistory() {
+func (tac *TakeAwayClient) OrderHistory() iter.Seq2[Order, error] {
+	return func(yield func(Order, error) bool) {
+		for page := 1; ; page++ {
+			historyPage, err := tac.GetOrderHistory(page)
+			if err != nil {
+				yield(Order{}, err)
+				return
+			}
+			for _, order := range historyPage.Orders {
+				if !yield(order, nil) {
+					return
+				}
+			}
+			if len(historyPage.Orders) == 0 || page >= historyPage.Pagination.TotalPages {
+				return
+			}
+		}
+	}
+}
+
+// BasketChangeKind describes why an order line could not be re-ordered unchanged
+type BasketChangeKind int
+
+const (
+	// ProductRemoved means the product is not on the menu anymore
+	ProductRemoved BasketChangeKind = iota
+	// SideDishRemoved means one of the chosen side dishes is not available anymore, it was dropped from the basket
+	SideDishRemoved
+	// PriceChanged means the product is still available but the line costs a different amount.
+	// If side dishes were removed the difference includes their price.
+	PriceChanged
+	// PriceUnknown means the current price of the product or a side dish can't be parsed, Err holds why
+	PriceUnknown
+)
+
+// BasketChange is an order line which changed when rebuilding a basket from a past order
+type BasketChange struct {
+	Kind     BasketChangeKind
+	Line     OrderLine
+	OldPrice Money
+	NewPrice Money
+	// SideDishIDs are the removed side dishes for SideDishRemoved
+	SideDishIDs []string
+	// Err is the parse error for PriceUnknown
+	Err error
+}
+
+// RebuildBasket creates a basket repeating a past order against the current menu of the restaurant.
+// Products which are not on the menu anymore are left out, unavailable side dishes are dropped
+// and all differences to the past order are reported as changes.
+func RebuildBasket(order Order, restaurant RestaurantData) (Basket, []BasketChange) {
+	basket := Basket{RestaurantID: order.RestaurantID, Mode: order.Mode, Postcode: order.Address.Postcode}
+	var changes []BasketChange
+	for _, line := range order.Lines {
+		product, ok := restaurant.FindProduct(line.ProductID)
+		if !ok {
+			changes = append(changes, BasketChange{Kind: ProductRemoved, Line: line, OldPrice: line.UnitPrice})
+			continue
+		}
+
+		item := BasketItem{ProductID: line.ProductID, Quantity: line.Quantity, Remark: line.Remark}
+		var removed []string
+		for _, sideDishID := range line.SideDishIDs {
+			if product.hasSideDish(sideDishID) {
+				item.SideDishIDs = append(item.SideDishIDs, sideDishID)
+			} else {
+				removed = append(removed, sideDishID)
+			}
+		}
+		if len(removed) > 0 {
+			changes = append(changes, BasketChange{Kind: SideDishRemoved, Line: line, SideDishIDs: removed})
+		}
+		basket.Items = append(basket.Items, item)
+
+		price, err := itemPrice(product, item, order.Mode)
+		switch {
+		case err != nil:
+			changes = append(changes, BasketChange{Kind: PriceUnknown, Line: line, OldPrice: line.UnitPrice, Err: err})
+		case price != line.UnitPrice:
+			changes = append(changes, BasketChange{Kind: PriceChanged, Line: line, OldPrice: line.UnitPrice, NewPrice: price})
+		}
+	}
+	return basket, changes
+}
+
+// hasSideDish reports whether the side dish option is available for the product
+func (p Product) hasSideDish(sideDishID string) bool {
+	for _, sideDish := range p.SideItems.SideDishes {
+		for _, choice := range sideDish.Cc.Ch {
+			if choice.ID == sideDishID {
+				return true
+			}
+		}
+	}
+	return false
+}
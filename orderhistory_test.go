@@ -0,0 +1,93 @@
+package takeawayapi
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestOrderHistory(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{})
+	ts.SetHandler("getorderhistory", func(form url.Values) string {
+		if form.Get("var2") == "1" {
+			return `{"oh":{"or":[{"oi":"A","ti":"2024-01-01 12:00:00"},{"oi":"B","ti":"2024-01-02 12:00:00"}],"pg":{"cp":1,"tp":2}}}`
+		}
+		return `{"oh":{"or":[{"oi":"C","ti":"2024-01-03 12:00:00","tt":{"tt":"23.50"}}],"pg":{"cp":2,"tp":2}}}`
+	})
+
+	var orderIDs []string
+	var last Order
+	for order, err := range tac.OrderHistory() {
+		if err != nil {
+			t.Fatalf(`OrderHistory errored with error: %v`, err)
+		}
+		orderIDs = append(orderIDs, order.OrderID)
+		last = order
+	}
+	if len(orderIDs) != 3 || orderIDs[2] != "C" {
+		t.Fatalf(`OrderHistory returned wrong orders: %v`, orderIDs)
+	}
+	if last.Totals.Total != 2350 || last.PlacedAt.IsZero() {
+		t.Fatalf(`OrderHistory decoded order wrong: %+v`, last)
+	}
+
+	// Stopping early must not fetch further pages
+	for range tac.OrderHistory() {
+		break
+	}
+	if ts.Hits("getorderhistory") != 3 {
+		t.Fatalf(`Expected 3 page requests, got %v`, ts.Hits("getorderhistory"))
+	}
+}
+
+func TestRebuildBasket(t *testing.T) {
+	order := Order{
+		RestaurantID: "O3QQ11PN",
+		Mode:         OrderModePickup,
+		Lines: []OrderLine{
+			{ProductID: "p1", Quantity: 1, SideDishIDs: []string{"s1"}, UnitPrice: 850},
+			{ProductID: "p2", Quantity: 2, SideDishIDs: []string{"gone"}, UnitPrice: 850},
+			{ProductID: "p3", Quantity: 1, UnitPrice: 1000},
+			{ProductID: "old", Quantity: 1, UnitPrice: 500},
+		},
+	}
+	basket, changes := RebuildBasket(order, testRestaurantData(t))
+	if len(basket.Items) != 3 {
+		t.Fatalf(`RebuildBasket returned wrong items: %v`, basket.Items)
+	}
+	if len(basket.Items[1].SideDishIDs) != 0 {
+		t.Fatalf(`RebuildBasket kept unavailable side dish`)
+	}
+	expected := []BasketChangeKind{SideDishRemoved, PriceChanged, ProductRemoved}
+	if len(changes) != len(expected) {
+		t.Fatalf(`RebuildBasket returned wrong changes: %+v`, changes)
+	}
+	for i, kind := range expected {
+		if changes[i].Kind != kind {
+			t.Fatalf(`RebuildBasket change %d is %v, expected %v`, i, changes[i].Kind, kind)
+		}
+	}
+	if changes[1].OldPrice != 1000 || changes[1].NewPrice != 1100 {
+		t.Fatalf(`RebuildBasket reported wrong price change: %+v`, changes[1])
+	}
+
+	// A removed side dish doesn't hide a new price, a price which can't be parsed is reported
+	restaurant := testRestaurantData(t)
+	restaurant.Menu.CategorieStruct.Categories[0].ProductStruct.Products[0].PickupCost = "abc"
+	order.Lines = []OrderLine{
+		{ProductID: "p1", Quantity: 1, UnitPrice: 750},
+		{ProductID: "p2", Quantity: 1, SideDishIDs: []string{"gone"}, UnitPrice: 800},
+	}
+	_, changes = RebuildBasket(order, restaurant)
+	expected = []BasketChangeKind{PriceUnknown, SideDishRemoved, PriceChanged}
+	if len(changes) != len(expected) {
+		t.Fatalf(`RebuildBasket returned wrong changes: %+v`, changes)
+	}
+	for i, kind := range expected {
+		if changes[i].Kind != kind {
+			t.Fatalf(`RebuildBasket change %d is %v, expected %v`, i, changes[i].Kind, kind)
+		}
+	}
+	if changes[0].Err == nil || changes[2].OldPrice != 800 || changes[2].NewPrice != 850 {
+		t.Fatalf(`RebuildBasket reported wrong changes: %+v`, changes)
+	}
+}
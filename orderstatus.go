@@ -0,0 +1,180 @@
+package takeawayapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// OrderState is the processing state of a placed order
+type OrderState int
+
+const (
+	OrderStateUnknown OrderState = iota
+	OrderStateReceived
+	OrderStateConfirmed
+	OrderStateInKitchen
+	OrderStateOutForDelivery
+	OrderStateDelivered
+	OrderStateCancelled
+)
+
+var orderStateNames = map[OrderState]string{
+	OrderStateUnknown:        "unknown",
+	OrderStateReceived:       "received",
+	OrderStateConfirmed:      "confirmed",
+	OrderStateInKitchen:      "in kitchen",
+	OrderStateOutForDelivery: "out for delivery",
+	OrderStateDelivered:      "delivered",
+	OrderStateCancelled:      "cancelled",
+}
+
+func (s OrderState) String() string {
+	if name, ok := orderStateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("OrderState(%d)", int(s))
+}
+
+// IsTerminal reports whether the order will not change its state anymore
+func (s OrderState) IsTerminal() bool {
+	return s == OrderStateDelivered || s == OrderStateCancelled
+}
+
+type orderStatusResponse struct {
+	OrderStatus OrderStatus `json:"os"`
+}
+
+// OrderStatus is the current status of a placed order
+type OrderStatus struct {
+	OrderID                  string     `json:"oi"`
+	State                    OrderState `json:"st"`
+	EstimatedDeliveryTimeStr string     `json:"dt"`
+	EstimatedDeliveryTime    time.Time
+	CurrentTimeStr           string `json:"ct"`
+	CurrentTime              time.Time
+}
+
+// GetOrderStatus returns the current status of a placed order
+func (tac *TakeAwayClient) GetOrderStatus(orderID string) (OrderStatus, error) {
+	function := "getorderstatus"
+	var orderStatusResponse orderStatusResponse
+	err := tac.sendRequest(function, &orderStatusResponse, orderID)
+	if err != nil {
+		return OrderStatus{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	orderStatus := orderStatusResponse.OrderStatus
+	if orderStatus.EstimatedDeliveryTimeStr != "" {
+		orderStatus.EstimatedDeliveryTime, err = ParseTakeAwayTime(orderStatus.EstimatedDeliveryTimeStr)
+		if err != nil {
+			return OrderStatus{}, fmt.Errorf("error parsing estimated delivery time: %w", err)
+		}
+	}
+	if orderStatus.CurrentTimeStr != "" {
+		orderStatus.CurrentTime, err = ParseTakeAwayTime(orderStatus.CurrentTimeStr)
+		if err != nil {
+			return OrderStatus{}, fmt.Errorf("error parsing current time: %w", err)
+		}
+	}
+	return orderStatus, nil
+}
+
+// OrderTransition is emitted by a Watcher when the state of an order changes
+type OrderTransition struct {
+	From   OrderState
+	To     OrderState
+	Status OrderStatus
+}
+
+// WatcherOptions configures a Watcher
+type WatcherOptions struct {
+	// Interval is the initial polling interval, defaults to 30 seconds
+	Interval time.Duration
+	// MaxInterval caps the backoff of the polling interval, defaults to 5 minutes
+	MaxInterval time.Duration
+	// OnTransition is called for every state change
+	OnTransition func(OrderTransition)
+	// OnError is called for every failed poll, polling continues with backoff
+	OnError func(error)
+}
+
+// Watcher polls the status of an order and reports its state transitions
+type Watcher struct {
+	client  *TakeAwayClient
+	orderID string
+	opts    WatcherOptions
+}
+
+// NewWatcher creates a Watcher for the given order
+func (tac *TakeAwayClient) NewWatcher(orderID string, opts WatcherOptions) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	if opts.MaxInterval < opts.Interval {
+		opts.MaxInterval = max(5*time.Minute, opts.Interval)
+	}
+	return &Watcher{client: tac, orderID: orderID, opts: opts}
+}
+
+// Run polls the order status until the order reaches a terminal state or ctx is done.
+// The interval is reset after a state change and backs off while the state stays the same or polling fails.
+// It returns nil once a terminal state was reported and ctx.Err() on cancellation.
+func (w *Watcher) Run(ctx context.Context) error {
+	client := w.client.WithContext(ctx).WithCacheMode(CacheBypass)
+	state := OrderStateUnknown
+	interval := w.opts.Interval
+	for {
+		status, err := client.GetOrderStatus(w.orderID)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+			interval = min(interval*2, w.opts.MaxInterval)
+		case status.State != state:
+			if w.opts.OnTransition != nil {
+				w.opts.OnTransition(OrderTransition{From: state, To: status.State, Status: status})
+			}
+			state = status.State
+			if state.IsTerminal() {
+				return nil
+			}
+			interval = w.opts.Interval
+		default:
+			interval = min(interval*3/2, w.opts.MaxInterval)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Watch runs the watcher in the background and sends all transitions on the returned channel.
+// The channel is closed when the order reached a terminal state or ctx is done.
+// OnTransition is still called if set.
+func (w *Watcher) Watch(ctx context.Context) <-chan OrderTransition {
+	transitions := make(chan OrderTransition)
+	watcher := *w
+	watcher.opts.OnTransition = func(transition OrderTransition) {
+		if w.opts.OnTransition != nil {
+			w.opts.OnTransition(transition)
+		}
+		select {
+		case transitions <- transition:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer close(transitions)
+		watcher.Run(ctx)
+	}()
+	return transitions
+}
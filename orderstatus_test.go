@@ -0,0 +1,44 @@
+package takeawayapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOrderWatcher(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{"getorderstatus": `{"os":{"oi":"ORDER1","st":1}}`})
+	watcher := tac.NewWatcher("ORDER1", WatcherOptions{Interval: 5 * time.Millisecond, MaxInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	transitions := watcher.Watch(ctx)
+
+	var states []OrderState
+	for transition := range transitions {
+		states = append(states, transition.To)
+		switch transition.To {
+		case OrderStateReceived:
+			ts.SetResponse("getorderstatus", `{"os":{"oi":"ORDER1","st":4,"dt":"2024-01-01 12:45:00"}}`)
+		case OrderStateOutForDelivery:
+			if transition.Status.EstimatedDeliveryTime.IsZero() {
+				t.Fatalf(`Estimated delivery time was not parsed`)
+			}
+			ts.SetResponse("getorderstatus", `{"os":{"oi":"ORDER1","st":5}}`)
+		}
+	}
+	if len(states) != 3 || states[2] != OrderStateDelivered {
+		t.Fatalf(`Watcher emitted wrong transitions: %v`, states)
+	}
+}
+
+func TestOrderWatcherCancel(t *testing.T) {
+	tac, _ := newTestClient(t, map[string]string{"getorderstatus": `{"os":{"oi":"ORDER1","st":2}}`})
+	watcher := tac.NewWatcher("ORDER1", WatcherOptions{Interval: 5 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := watcher.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf(`Expected deadline exceeded, got %v`, err)
+	}
+}
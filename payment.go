@@ -0,0 +1,188 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PaymentMethod is the ID of a payment method ("mi" in RestaurantData.Pm.Me, "pm" in Country.Erp)
+type PaymentMethod int
+
+const (
+	PaymentCash        PaymentMethod = 0
+	PaymentCreditCard  PaymentMethod = 1
+	PaymentPayPal      PaymentMethod = 3
+	PaymentSofort      PaymentMethod = 4
+	PaymentIDEAL       PaymentMethod = 5
+	PaymentBancontact  PaymentMethod = 6
+	PaymentGiropay     PaymentMethod = 7
+	PaymentCardAtDoor  PaymentMethod = 8
+	PaymentVoucher     PaymentMethod = 9
+	PaymentApplePay    PaymentMethod = 10
+	PaymentGooglePay   PaymentMethod = 11
+	PaymentBLIK        PaymentMethod = 12
+	PaymentPrzelewy24  PaymentMethod = 13
+	PaymentMBWay       PaymentMethod = 14
+	PaymentKlarna      PaymentMethod = 15
+	PaymentPostFinance PaymentMethod = 16
+)
+
+var paymentMethodNames = map[PaymentMethod]string{
+	PaymentCash:        "cash",
+	PaymentCreditCard:  "credit card",
+	PaymentPayPal:      "PayPal",
+	PaymentSofort:      "Sofort",
+	PaymentIDEAL:       "iDEAL",
+	PaymentBancontact:  "Bancontact",
+	PaymentGiropay:     "giropay",
+	PaymentCardAtDoor:  "card at the door",
+	PaymentVoucher:     "voucher",
+	PaymentApplePay:    "Apple Pay",
+	PaymentGooglePay:   "Google Pay",
+	PaymentBLIK:        "BLIK",
+	PaymentPrzelewy24:  "Przelewy24",
+	PaymentMBWay:       "MB WAY",
+	PaymentKlarna:      "Klarna",
+	PaymentPostFinance: "PostFinance",
+}
+
+func (pm PaymentMethod) String() string {
+	if name, ok := paymentMethodNames[pm]; ok {
+		return name
+	}
+	return fmt.Sprintf("PaymentMethod(%d)", int(pm))
+}
+
+// Online reports whether the payment is completed online before the order is processed
+func (pm PaymentMethod) Online() bool {
+	return pm != PaymentCash && pm != PaymentCardAtDoor
+}
+
+// ParsePaymentMethod parses a payment method ID as sent by the API
+func ParsePaymentMethod(id string) (PaymentMethod, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		return 0, fmt.Errorf("invalid payment method %q: %w", id, err)
+	}
+	return PaymentMethod(value), nil
+}
+
+// UnmarshalJSON decodes a payment method ID sent as string or number
+func (pm *PaymentMethod) UnmarshalJSON(data []byte) error {
+	var id json.Number
+	if err := json.Unmarshal(data, &id); err != nil {
+		var idString string
+		if err := json.Unmarshal(data, &idString); err != nil {
+			return fmt.Errorf("failed to unmarshal payment method: data=%s", string(data))
+		}
+		id = json.Number(idString)
+	}
+	parsed, err := ParsePaymentMethod(id.String())
+	if err != nil {
+		return err
+	}
+	*pm = parsed
+	return nil
+}
+
+// PaymentFee is the surcharge of a payment method ("mf"), either a fixed amount or a percentage of the order
+type PaymentFee struct {
+	Fixed      Money
+	Percentage float64
+}
+
+// ParsePaymentFee parses a fee like "0.50" or "2%". An empty fee is no fee.
+func ParsePaymentFee(fee string) (PaymentFee, error) {
+	fee = strings.TrimSpace(fee)
+	if percentage, ok := strings.CutSuffix(fee, "%"); ok {
+		value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(percentage), ",", "."), 64)
+		if err != nil {
+			return PaymentFee{}, fmt.Errorf("invalid payment fee %q: %w", fee, err)
+		}
+		return PaymentFee{Percentage: value}, nil
+	}
+	fixed, err := ParseMoney(fee)
+	if err != nil {
+		return PaymentFee{}, fmt.Errorf("invalid payment fee %q: %w", fee, err)
+	}
+	return PaymentFee{Fixed: fixed}, nil
+}
+
+// For returns the fee charged for an order of amount
+func (f PaymentFee) For(amount Money) Money {
+	return f.Fixed + Money(math.Round(float64(amount)*f.Percentage/100))
+}
+
+// PaymentOption is a payment method offered by a restaurant
+type PaymentOption struct {
+	Method PaymentMethod
+	// Name is the name of the method as sent by the restaurant ("mt")
+	Name string
+	Fee  PaymentFee
+	// FeeAmount is the fee for the basket, set by UsablePaymentMethods
+	FeeAmount Money
+}
+
+// PaymentOptions returns the payment methods offered by the restaurant
+func (rd RestaurantData) PaymentOptions() ([]PaymentOption, error) {
+	options := make([]PaymentOption, 0, len(rd.Pm.Me))
+	for _, method := range rd.Pm.Me {
+		id, err := ParsePaymentMethod(method.Mi)
+		if err != nil {
+			return nil, err
+		}
+		fee, err := ParsePaymentFee(method.Mf)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, PaymentOption{Method: id, Name: method.Mt, Fee: fee})
+	}
+	return options, nil
+}
+
+// PaymentMethods returns the IDs of the payment methods offered by the restaurant, unparseable IDs are skipped
+func (r Restaurant) PaymentMethods() []PaymentMethod {
+	methods := make([]PaymentMethod, 0, len(r.Pm.Me))
+	for _, method := range r.Pm.Me {
+		if id, err := ParsePaymentMethod(method.Mi); err == nil {
+			methods = append(methods, id)
+		}
+	}
+	return methods
+}
+
+// PaymentMethods returns the payment methods available in the country
+func (c Country) PaymentMethods() []PaymentMethod {
+	methods := make([]PaymentMethod, 0, len(c.Erp.Pm))
+	for _, id := range c.Erp.Pm {
+		methods = append(methods, PaymentMethod(id))
+	}
+	return methods
+}
+
+// UsablePaymentMethods returns the payment options of the restaurant which can be used in the country,
+// with the fee calculated for the basket total. If the country lists no payment methods it does not restrict them.
+func (rd RestaurantData) UsablePaymentMethods(basket ValidatedBasket, country Country) ([]PaymentOption, error) {
+	options, err := rd.PaymentOptions()
+	if err != nil {
+		return nil, err
+	}
+	countryMethods := country.PaymentMethods()
+	usable := make([]PaymentOption, 0, len(options))
+	for _, option := range options {
+		if len(countryMethods) > 0 && !slices.Contains(countryMethods, option.Method) {
+			continue
+		}
+		// Vouchers are applied through the basket, not chosen as payment method
+		if option.Method == PaymentVoucher {
+			continue
+		}
+		option.FeeAmount = option.Fee.For(basket.Totals.Total)
+		usable = append(usable, option)
+	}
+	return usable, nil
+}
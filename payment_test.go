@@ -0,0 +1,70 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParsePaymentFee(t *testing.T) {
+	tests := map[string]PaymentFee{
+		"":     {},
+		"0":    {},
+		"0.50": {Fixed: 50},
+		"2%":   {Percentage: 2},
+		"1,5%": {Percentage: 1.5},
+	}
+	for fee, expected := range tests {
+		parsed, err := ParsePaymentFee(fee)
+		if err != nil {
+			t.Fatalf(`ParsePaymentFee(%q) errored with error: %v`, fee, err)
+		}
+		if parsed != expected {
+			t.Fatalf(`ParsePaymentFee(%q) returned %+v`, fee, parsed)
+		}
+	}
+	if (PaymentFee{Fixed: 25, Percentage: 2}).For(2000) != 65 {
+		t.Fatalf(`PaymentFee.For calculated wrong fee`)
+	}
+}
+
+func TestUsablePaymentMethods(t *testing.T) {
+	restaurant := testRestaurantData(t)
+	restaurant.Pm.Me = append(restaurant.Pm.Me,
+		PaymentMethodData{Mi: "3", Mt: "PayPal", Mf: "2%"},
+		PaymentMethodData{Mi: "5", Mt: "iDEAL"},
+	)
+	basket := Basket{RestaurantID: "O3QQ11PN", Mode: OrderModePickup}
+	basket.Add("p3", 1)
+	validated, err := basket.Validate(restaurant)
+	if err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+
+	var country Country
+	if err := json.Unmarshal([]byte(`{"cy":"DE","erp":{"pm":[0,1,3]}}`), &country); err != nil {
+		t.Fatalf(`Failed to decode country: %v`, err)
+	}
+	options, err := restaurant.UsablePaymentMethods(validated, country)
+	if err != nil {
+		t.Fatalf(`UsablePaymentMethods errored with error: %v`, err)
+	}
+	if len(options) != 2 || options[0].Method != PaymentCash || options[1].Method != PaymentPayPal {
+		t.Fatalf(`UsablePaymentMethods returned wrong methods: %+v`, options)
+	}
+	if options[1].FeeAmount != 22 {
+		t.Fatalf(`UsablePaymentMethods calculated wrong fee: %v`, options[1].FeeAmount)
+	}
+	if PaymentPayPal.String() != "PayPal" || !PaymentPayPal.Online() || PaymentCash.Online() {
+		t.Fatalf(`PaymentMethod names or online flags are wrong`)
+	}
+}
+
+func TestPaymentMethodJSON(t *testing.T) {
+	var methods []PaymentMethod
+	if err := json.Unmarshal([]byte(`["3", 5]`), &methods); err != nil {
+		t.Fatalf(`Unmarshal errored with error: %v`, err)
+	}
+	if methods[0] != PaymentPayPal || methods[1] != PaymentIDEAL {
+		t.Fatalf(`Unmarshal returned wrong methods: %v`, methods)
+	}
+}
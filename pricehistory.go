@@ -0,0 +1,278 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoPriceHistory is returned if nothing was recorded for a restaurant or product
+var ErrNoPriceHistory = errors.New("no price history")
+
+// PricePoint are the prices of a product from Time on
+type PricePoint struct {
+	Time          time.Time `json:"time"`
+	PickupPrice   Money     `json:"pickupPrice"`
+	DeliveryPrice Money     `json:"deliveryPrice"`
+}
+
+// Price returns the price for the order mode
+func (p PricePoint) Price(mode OrderMode) Money {
+	if mode == OrderModePickup {
+		return p.PickupPrice
+	}
+	return p.DeliveryPrice
+}
+
+// ProductHistory is the recorded history of a product.
+// Prices only gets a new point when a price changed.
+type ProductHistory struct {
+	RestaurantID string       `json:"restaurantId"`
+	ProductID    string       `json:"productId"`
+	Name         string       `json:"name"`
+	FirstSeen    time.Time    `json:"firstSeen"`
+	LastSeen     time.Time    `json:"lastSeen"`
+	Prices       []PricePoint `json:"prices"`
+}
+
+// PriceAt returns the prices valid at t
+func (h ProductHistory) PriceAt(t time.Time) (PricePoint, bool) {
+	for i := len(h.Prices) - 1; i >= 0; i-- {
+		if !h.Prices[i].Time.After(t) {
+			return h.Prices[i], true
+		}
+	}
+	return PricePoint{}, false
+}
+
+// restaurantHistory is the file content of a restaurant
+type restaurantHistory struct {
+	RestaurantID string                     `json:"restaurantId"`
+	Name         string                     `json:"name"`
+	Snapshots    []time.Time                `json:"snapshots"`
+	Products     map[string]*ProductHistory `json:"products"`
+}
+
+// PriceHistory stores the menu prices of restaurants over time in a directory, one JSON file per restaurant.
+// It works entirely offline and is safe for concurrent use within a process.
+type PriceHistory struct {
+	dir string
+
+	mu          sync.Mutex
+	restaurants map[string]*restaurantHistory
+}
+
+// OpenPriceHistory opens the price history in dir, creating the directory if needed
+func OpenPriceHistory(dir string) (*PriceHistory, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &PriceHistory{dir: dir, restaurants: map[string]*restaurantHistory{}}, nil
+}
+
+func (ph *PriceHistory) path(restaurantID string) string {
+	return filepath.Join(ph.dir, url.PathEscape(restaurantID)+".json")
+}
+
+// load returns the history of the restaurant, reading it from disk the first time. ph.mu must be held.
+func (ph *PriceHistory) load(restaurantID string) (*restaurantHistory, error) {
+	if history, ok := ph.restaurants[restaurantID]; ok {
+		return history, nil
+	}
+	history := &restaurantHistory{RestaurantID: restaurantID, Products: map[string]*ProductHistory{}}
+	content, err := os.ReadFile(ph.path(restaurantID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err != nil {
+		// Unknown restaurants aren't cached, Record adds them once they are written
+		return history, nil
+	}
+	if err := json.Unmarshal(content, history); err != nil {
+		return nil, fmt.Errorf("error decoding price history of restaurant %s: %w", restaurantID, err)
+	}
+	ph.restaurants[restaurantID] = history
+	return history, nil
+}
+
+// Record stores a snapshot of the menu prices of the restaurant taken at the given time.
+// Snapshots have to be recorded in chronological order.
+// Products whose price can't be parsed are left out of the snapshot, their IDs are returned as skipped.
+func (ph *PriceHistory) Record(restaurant RestaurantData, at time.Time) (skipped []string, err error) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+	history, err := ph.load(restaurant.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(history.Snapshots); n > 0 && at.Before(history.Snapshots[n-1]) {
+		return nil, fmt.Errorf("snapshot at %v is older than the last snapshot at %v", at, history.Snapshots[n-1])
+	}
+
+	var products []Product
+	var points []PricePoint
+	for _, product := range restaurant.Products() {
+		point := PricePoint{Time: at}
+		if point.PickupPrice, err = product.Price(OrderModePickup); err == nil {
+			point.DeliveryPrice, err = product.Price(OrderModeDelivery)
+		}
+		if err != nil {
+			skipped = append(skipped, product.ID)
+			continue
+		}
+		products = append(products, product)
+		points = append(points, point)
+	}
+
+	// The snapshot is added to a copy which replaces the cached history only once it is written,
+	// so a failed write can be retried without recording the snapshot twice
+	updated := &restaurantHistory{
+		RestaurantID: history.RestaurantID,
+		Name:         restaurant.Name,
+		Snapshots:    append(slices.Clone(history.Snapshots), at),
+		Products:     make(map[string]*ProductHistory, len(history.Products)),
+	}
+	for id, productHistory := range history.Products {
+		clone := productHistory.clone()
+		updated.Products[id] = &clone
+	}
+	for i, product := range products {
+		productHistory, ok := updated.Products[product.ID]
+		if !ok {
+			productHistory = &ProductHistory{RestaurantID: restaurant.RestaurantID, ProductID: product.ID, FirstSeen: at}
+			updated.Products[product.ID] = productHistory
+		}
+		productHistory.Name = product.Name
+		productHistory.LastSeen = at
+		point := points[i]
+		if n := len(productHistory.Prices); n == 0 || productHistory.Prices[n-1].PickupPrice != point.PickupPrice || productHistory.Prices[n-1].DeliveryPrice != point.DeliveryPrice {
+			productHistory.Prices = append(productHistory.Prices, point)
+		}
+	}
+
+	content, err := json.Marshal(updated)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(ph.path(restaurant.RestaurantID), content); err != nil {
+		return nil, err
+	}
+	ph.restaurants[restaurant.RestaurantID] = updated
+	return skipped, nil
+}
+
+// Restaurants returns the IDs of all restaurants with a recorded history
+func (ph *PriceHistory) Restaurants() ([]string, error) {
+	entries, err := os.ReadDir(ph.dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		id, err := url.PathUnescape(name)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Products returns the history of all products ever seen at the restaurant ordered by product ID
+func (ph *PriceHistory) Products(restaurantID string) ([]ProductHistory, error) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+	history, err := ph.load(restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(history.Snapshots) == 0 {
+		return nil, ErrNoPriceHistory
+	}
+	products := make([]ProductHistory, 0, len(history.Products))
+	for _, product := range history.Products {
+		products = append(products, product.clone())
+	}
+	slices.SortFunc(products, func(a, b ProductHistory) int { return strings.Compare(a.ProductID, b.ProductID) })
+	return products, nil
+}
+
+// Product returns the history of a single product
+func (ph *PriceHistory) Product(restaurantID, productID string) (ProductHistory, error) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+	history, err := ph.load(restaurantID)
+	if err != nil {
+		return ProductHistory{}, err
+	}
+	product, ok := history.Products[productID]
+	if !ok {
+		return ProductHistory{}, ErrNoPriceHistory
+	}
+	return product.clone(), nil
+}
+
+// clone copies the history so callers can't modify the stored prices
+func (h *ProductHistory) clone() ProductHistory {
+	clone := *h
+	clone.Prices = slices.Clone(h.Prices)
+	return clone
+}
+
+// snapshotAt returns the time of the last snapshot taken at or before t
+func (h *restaurantHistory) snapshotAt(t time.Time) (time.Time, bool) {
+	for i := len(h.Snapshots) - 1; i >= 0; i-- {
+		if !h.Snapshots[i].After(t) {
+			return h.Snapshots[i], true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Inflation returns the relative price change of the menu of the restaurant between from and to, e.g. 0.05 for 5%.
+// The menus of the last snapshots at or before from and to are compared,
+// using only products on both menus, weighted by their price at from.
+func (ph *PriceHistory) Inflation(restaurantID string, from, to time.Time, mode OrderMode) (float64, error) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+	history, err := ph.load(restaurantID)
+	if err != nil {
+		return 0, err
+	}
+	if len(history.Snapshots) == 0 {
+		return 0, ErrNoPriceHistory
+	}
+	fromSnapshot, okFrom := history.snapshotAt(from)
+	toSnapshot, okTo := history.snapshotAt(to)
+	if !okFrom {
+		return 0, fmt.Errorf("no snapshot of restaurant %s at %v", restaurantID, from)
+	}
+	if !okTo {
+		return 0, fmt.Errorf("no snapshot of restaurant %s at %v", restaurantID, to)
+	}
+	var before, after Money
+	for _, product := range history.Products {
+		if product.FirstSeen.After(fromSnapshot) || product.LastSeen.Before(toSnapshot) {
+			continue
+		}
+		fromPrice, _ := product.PriceAt(fromSnapshot)
+		toPrice, _ := product.PriceAt(toSnapshot)
+		before += fromPrice.Price(mode)
+		after += toPrice.Price(mode)
+	}
+	if before == 0 {
+		return 0, fmt.Errorf("no prices of restaurant %s to compare between %v and %v", restaurantID, from, to)
+	}
+	return float64(after-before) / float64(before), nil
+}
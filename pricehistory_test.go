@@ -0,0 +1,255 @@
+package takeawayapi
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// priceHistoryMenu returns a menu of restaurant R1 with the given products in a single category
+func priceHistoryMenu(t *testing.T, products ...string) RestaurantData {
+	t.Helper()
+	return testMenu(t, "R1", `{"id":"c1","nm":"Pizza","ps":{"pr":[`+strings.Join(products, ",")+`]}}`)
+}
+
+func TestPriceHistory(t *testing.T) {
+	const (
+		margherita        = `{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8.00"}`
+		margheritaClassic = `{"id":"p1","nm":"Margherita Classica","pc":"7.50","tc":"8.50"}`
+		salami            = `{"id":"p2","nm":"Salami","pc":"8.50","tc":"9.00"}`
+		tonno             = `{"id":"p3","nm":"Tonno","pc":"9.00","tc":"9.50"}`
+	)
+	dir := t.TempDir()
+	history, err := OpenPriceHistory(dir)
+	if err != nil {
+		t.Fatalf(`Failed to open price history: %v`, err)
+	}
+	monday := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	wednesday := monday.AddDate(0, 0, 2)
+	snapshots := []struct {
+		at       time.Time
+		products []string
+	}{
+		{monday, []string{margherita, salami}},
+		{tuesday, []string{margherita, salami}},
+		{wednesday, []string{margheritaClassic, tonno}},
+	}
+	for _, snapshot := range snapshots {
+		if _, err := history.Record(priceHistoryMenu(t, snapshot.products...), snapshot.at); err != nil {
+			t.Fatalf(`Failed to record %v: %v`, snapshot.at, err)
+		}
+	}
+	if _, err := history.Record(priceHistoryMenu(t, margherita), monday); err == nil {
+		t.Errorf(`Expected an error recording an older snapshot`)
+	}
+
+	// Everything has to be read back from disk
+	history, err = OpenPriceHistory(dir)
+	if err != nil {
+		t.Fatalf(`Failed to reopen price history: %v`, err)
+	}
+	restaurants, err := history.Restaurants()
+	if want := []string{"R1"}; err != nil || !slices.Equal(restaurants, want) {
+		t.Errorf(`Restaurants() = %v, %v, want %v`, restaurants, err, want)
+	}
+
+	got, err := history.Product("R1", "p1")
+	if err != nil {
+		t.Fatalf(`Failed to get product history: %v`, err)
+	}
+	wantPrices := []PricePoint{
+		{Time: monday, PickupPrice: 750, DeliveryPrice: 800},
+		{Time: wednesday, PickupPrice: 750, DeliveryPrice: 850},
+	}
+	if !slices.EqualFunc(got.Prices, wantPrices, func(a, b PricePoint) bool {
+		return a.Time.Equal(b.Time) && a.PickupPrice == b.PickupPrice && a.DeliveryPrice == b.DeliveryPrice
+	}) {
+		t.Errorf(`Prices = %+v, want %+v`, got.Prices, wantPrices)
+	}
+	if got.Name != "Margherita Classica" || !got.FirstSeen.Equal(monday) || !got.LastSeen.Equal(wednesday) {
+		t.Errorf(`Unexpected product history %+v`, got)
+	}
+	if price, ok := got.PriceAt(tuesday); !ok || price.DeliveryPrice != 800 {
+		t.Errorf(`PriceAt(tuesday) = %v, want delivery price 8.00`, price.DeliveryPrice)
+	}
+
+	products, err := history.Products("R1")
+	if err != nil {
+		t.Fatalf(`Failed to get products: %v`, err)
+	}
+	seen := map[string][2]time.Time{}
+	for _, product := range products {
+		seen[product.ProductID] = [2]time.Time{product.FirstSeen, product.LastSeen}
+	}
+	wantSeen := map[string][2]time.Time{
+		"p1": {monday, wednesday},
+		"p2": {monday, tuesday},
+		"p3": {wednesday, wednesday},
+	}
+	if !maps.EqualFunc(seen, wantSeen, func(a, b [2]time.Time) bool { return a[0].Equal(b[0]) && a[1].Equal(b[1]) }) {
+		t.Errorf(`First and last seen = %v, want %v`, seen, wantSeen)
+	}
+
+	if _, err := history.Product("UNKNOWN", "p1"); !errors.Is(err, ErrNoPriceHistory) {
+		t.Errorf(`Expected ErrNoPriceHistory, got %v`, err)
+	}
+}
+
+func TestPriceHistoryInflation(t *testing.T) {
+	monday := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	tests := []struct {
+		name          string
+		before, after []string
+		mode          OrderMode
+		want          float64
+	}{
+		{
+			name:   "unchanged",
+			before: []string{`{"id":"p1","pc":"7.50","tc":"8.00"}`},
+			after:  []string{`{"id":"p1","pc":"7.50","tc":"8.00"}`},
+			mode:   OrderModeDelivery,
+		},
+		{
+			name:   "weighted by price",
+			before: []string{`{"id":"p1","pc":"7.50","tc":"8.00"}`, `{"id":"p2","pc":"11.00","tc":"12.00"}`},
+			after:  []string{`{"id":"p1","pc":"7.50","tc":"9.00"}`, `{"id":"p2","pc":"11.00","tc":"12.00"}`},
+			mode:   OrderModeDelivery,
+			want:   100.0 / 2000.0,
+		},
+		{
+			name:   "per mode",
+			before: []string{`{"id":"p1","pc":"7.50","tc":"8.00"}`},
+			after:  []string{`{"id":"p1","pc":"7.50","tc":"9.00"}`},
+			mode:   OrderModePickup,
+		},
+		{
+			name:   "only products on both menus",
+			before: []string{`{"id":"p1","pc":"8.00","tc":"8.00"}`, `{"id":"p2","pc":"5.00","tc":"5.00"}`},
+			after:  []string{`{"id":"p1","pc":"7.20","tc":"7.20"}`, `{"id":"p3","pc":"50.00","tc":"50.00"}`},
+			mode:   OrderModePickup,
+			want:   -0.1,
+		},
+	}
+	for _, test := range tests {
+		history, err := OpenPriceHistory(t.TempDir())
+		if err != nil {
+			t.Fatalf(`Failed to open price history: %v`, err)
+		}
+		if _, err := history.Record(priceHistoryMenu(t, test.before...), monday); err != nil {
+			t.Fatalf(`%s: Failed to record: %v`, test.name, err)
+		}
+		if _, err := history.Record(priceHistoryMenu(t, test.after...), tuesday); err != nil {
+			t.Fatalf(`%s: Failed to record: %v`, test.name, err)
+		}
+		got, err := history.Inflation("R1", monday, tuesday, test.mode)
+		if err != nil || math.Abs(got-test.want) > 1e-9 {
+			t.Errorf(`%s: Inflation() = %f, %v, want %f`, test.name, got, err, test.want)
+		}
+	}
+}
+
+func TestPriceHistoryInflationErrors(t *testing.T) {
+	history, err := OpenPriceHistory(t.TempDir())
+	if err != nil {
+		t.Fatalf(`Failed to open price history: %v`, err)
+	}
+	monday := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sunday := monday.AddDate(0, 0, -1)
+	if _, err := history.Record(priceHistoryMenu(t, `{"id":"p1","pc":"7.50","tc":"8.00"}`), monday); err != nil {
+		t.Fatalf(`Failed to record: %v`, err)
+	}
+	if _, err := history.Inflation("UNKNOWN", monday, monday, OrderModeDelivery); !errors.Is(err, ErrNoPriceHistory) {
+		t.Errorf(`Expected ErrNoPriceHistory, got %v`, err)
+	}
+	if _, err := history.Inflation("R1", monday, sunday, OrderModeDelivery); err == nil || !strings.Contains(err.Error(), sunday.String()) {
+		t.Errorf(`Expected an error naming the time without snapshot, got %v`, err)
+	}
+}
+
+func TestPriceHistoryFailedWrite(t *testing.T) {
+	history, err := OpenPriceHistory(t.TempDir())
+	if err != nil {
+		t.Fatalf(`Failed to open price history: %v`, err)
+	}
+	monday := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	before := priceHistoryMenu(t, `{"id":"p1","pc":"7.50","tc":"8.00"}`)
+	after := priceHistoryMenu(t, `{"id":"p1","pc":"7.50","tc":"8.50"}`, `{"id":"p2","pc":"9.00","tc":"9.50"}`)
+	if _, err := history.Record(before, monday); err != nil {
+		t.Fatalf(`Failed to record: %v`, err)
+	}
+	// A directory in place of the file makes the next write fail
+	path := history.path("R1")
+	if err := os.Remove(path); err != nil {
+		t.Fatalf(`Failed to remove file: %v`, err)
+	}
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatalf(`Failed to create directory: %v`, err)
+	}
+	if _, err := history.Record(after, tuesday); err == nil {
+		t.Fatalf(`Expected the write to fail`)
+	}
+	if snapshots := history.restaurants["R1"].Snapshots; len(snapshots) != 1 {
+		t.Errorf(`Expected the failed snapshot to be discarded, got %v`, snapshots)
+	}
+	if _, err := history.Product("R1", "p2"); !errors.Is(err, ErrNoPriceHistory) {
+		t.Errorf(`Expected no history of the new product, got %v`, err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf(`Failed to remove directory: %v`, err)
+	}
+	if _, err := history.Record(after, tuesday); err != nil {
+		t.Fatalf(`Failed to record: %v`, err)
+	}
+	if snapshots := history.restaurants["R1"].Snapshots; len(snapshots) != 2 {
+		t.Errorf(`Expected two snapshots after the retry, got %v`, snapshots)
+	}
+	if product, err := history.Product("R1", "p1"); err != nil || len(product.Prices) != 2 {
+		t.Errorf(`Unexpected product history %+v: %v`, product, err)
+	}
+}
+
+func TestPriceHistorySkipsUnparseablePrices(t *testing.T) {
+	history, err := OpenPriceHistory(t.TempDir())
+	if err != nil {
+		t.Fatalf(`Failed to open price history: %v`, err)
+	}
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	restaurant := priceHistoryMenu(t, `{"id":"ok","pc":"1.00","tc":"1.50"}`, `{"id":"broken","pc":"n/a","tc":"1.00"}`)
+	skipped, err := history.Record(restaurant, at)
+	if err != nil {
+		t.Fatalf(`Failed to record: %v`, err)
+	}
+	if want := []string{"broken"}; !slices.Equal(skipped, want) {
+		t.Errorf(`Record skipped %v, want %v`, skipped, want)
+	}
+	if _, err := history.Product("R1", "ok"); err != nil {
+		t.Errorf(`Expected the parseable product to be recorded, got %v`, err)
+	}
+	if _, err := history.Product("R1", "broken"); !errors.Is(err, ErrNoPriceHistory) {
+		t.Errorf(`Expected the broken product to be skipped, got %v`, err)
+	}
+}
+
+func TestPriceHistoryDoesNotCacheUnknown(t *testing.T) {
+	history, err := OpenPriceHistory(t.TempDir())
+	if err != nil {
+		t.Fatalf(`Failed to open price history: %v`, err)
+	}
+	for _, id := range []string{"X1", "X2", "X3"} {
+		if _, err := history.Product(id, "p1"); !errors.Is(err, ErrNoPriceHistory) {
+			t.Errorf(`Product(%s) = %v, want ErrNoPriceHistory`, id, err)
+		}
+	}
+	if got := len(history.restaurants); got != 0 {
+		t.Errorf(`Expected no cached restaurants, got %d`, got)
+	}
+}
@@ -0,0 +1,261 @@
+package takeawayapi
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+// earthRadiusKm is the mean earth radius used for distances
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two coordinates in kilometers
+func (c Coordinates) DistanceKm(other Coordinates) float64 {
+	lat1, lat2 := c.Latitude*math.Pi/180, other.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (other.Longitude - c.Longitude) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// MinimumOrder returns the minimum order for delivery
+func (r Restaurant) MinimumOrder() (Money, error) {
+	return ParseMoney(r.Dc.Ma)
+}
+
+// DeliveryFee returns the delivery costs for an order of the minimum order amount,
+// it returns an error if the restaurant lists no delivery cost tier for that amount
+func (r Restaurant) DeliveryFee() (Money, error) {
+	minimum, err := r.MinimumOrder()
+	if err != nil {
+		return 0, err
+	}
+	return deliveryCosts(r.Dc.Co, minimum)
+}
+
+// HasCuisine reports whether the restaurant lists the cuisine by ID or name, names are compared case-insensitively
+func (r Restaurant) HasCuisine(cuisine string) bool {
+	for _, listed := range r.Cuisines() {
+		if listed.ID == cuisine || strings.EqualFold(listed.Name, cuisine) {
+			return true
+		}
+	}
+	return false
+}
+
+// RestaurantSort is the order of a RestaurantQuery result
+type RestaurantSort int
+
+const (
+	// SortDefault keeps the order of the API
+	SortDefault RestaurantSort = iota
+	// SortByETA sorts by the fastest estimated delivery first
+	SortByETA
+	// SortByRating sorts by the best rating first
+	SortByRating
+	// SortByDeliveryFee sorts by the cheapest delivery first
+	SortByDeliveryFee
+	// SortByDistance sorts by the nearest restaurant first, requires From or Within
+	SortByDistance
+)
+
+// RestaurantQuery filters and sorts the restaurants of a RestaurantsResponse.
+// All filters are combined, restaurants missing the data a filter needs are left out.
+//
+//	page := takeawayapi.NewRestaurantQuery(restaurants).Cuisine("Pizza").OpenNow().SortBy(takeawayapi.SortByRating).Page(1, 20)
+type RestaurantQuery struct {
+	restaurants []Restaurant
+	filters     []func(Restaurant) bool
+	sort        RestaurantSort
+	origin      *Coordinates
+}
+
+// RestaurantPage is a page of a RestaurantQuery result
+type RestaurantPage struct {
+	Restaurants []Restaurant
+	// Page is the page number starting at 1
+	Page       int
+	PageSize   int
+	Total      int
+	TotalPages int
+}
+
+// NewRestaurantQuery creates a query over the restaurants of the response
+func NewRestaurantQuery(response RestaurantsResponse) *RestaurantQuery {
+	return &RestaurantQuery{restaurants: response.Restaurants}
+}
+
+// Where adds a custom filter
+func (q *RestaurantQuery) Where(filter func(Restaurant) bool) *RestaurantQuery {
+	q.filters = append(q.filters, filter)
+	return q
+}
+
+// Cuisine keeps restaurants listing any of the cuisines
+func (q *RestaurantQuery) Cuisine(cuisines ...string) *RestaurantQuery {
+	return q.Where(func(r Restaurant) bool {
+		return slices.ContainsFunc(cuisines, r.HasCuisine)
+	})
+}
+
+// OpenNow keeps restaurants where delivery or pickup is open right now
+func (q *RestaurantQuery) OpenNow() *RestaurantQuery {
+	return q.Where(func(r Restaurant) bool {
+		return r.Dm.Delivery.State == OperatingOpen || r.Dm.Pickup.State == OperatingOpen
+	})
+}
+
+// Delivery keeps restaurants supporting delivery
+func (q *RestaurantQuery) Delivery() *RestaurantQuery {
+	return q.Where(func(r Restaurant) bool { return r.Dm.SupportsDelivery() })
+}
+
+// Pickup keeps restaurants supporting pickup
+func (q *RestaurantQuery) Pickup() *RestaurantQuery {
+	return q.Where(func(r Restaurant) bool { return r.Dm.SupportsPickup() })
+}
+
+// MinimumOrderBelow keeps restaurants whose minimum order is below amount
+func (q *RestaurantQuery) MinimumOrderBelow(amount Money) *RestaurantQuery {
+	return q.Where(func(r Restaurant) bool {
+		minimum, err := r.MinimumOrder()
+		return err == nil && minimum < amount
+	})
+}
+
+// DeliveryFeeBelow keeps restaurants whose delivery fee is below amount
+func (q *RestaurantQuery) DeliveryFeeBelow(amount Money) *RestaurantQuery {
+	return q.Where(func(r Restaurant) bool {
+		fee, err := r.DeliveryFee()
+		return err == nil && fee < amount
+	})
+}
+
+// RatingAbove keeps restaurants rated above stars
+func (q *RestaurantQuery) RatingAbove(stars float64) *RestaurantQuery {
+	return q.Where(func(r Restaurant) bool { return r.Rating().Stars > stars })
+}
+
+// New keeps restaurants marked as new
+func (q *RestaurantQuery) New() *RestaurantQuery {
+	return q.Where(func(r Restaurant) bool { return r.New != 0 })
+}
+
+// From sets the origin used for distances
+func (q *RestaurantQuery) From(origin Coordinates) *RestaurantQuery {
+	q.origin = &origin
+	return q
+}
+
+// Within keeps restaurants at most km away from origin
+func (q *RestaurantQuery) Within(origin Coordinates, km float64) *RestaurantQuery {
+	q.From(origin)
+	return q.Where(func(r Restaurant) bool {
+		coordinates, err := r.Address.Coordinates()
+		return err == nil && origin.DistanceKm(coordinates) <= km
+	})
+}
+
+// SortBy sets the order of the result
+func (q *RestaurantQuery) SortBy(sort RestaurantSort) *RestaurantQuery {
+	q.sort = sort
+	return q
+}
+
+// distance returns the distance of the restaurant from the origin
+func (q *RestaurantQuery) distance(r Restaurant) (float64, bool) {
+	if q.origin == nil {
+		return 0, false
+	}
+	coordinates, err := r.Address.Coordinates()
+	if err != nil {
+		return 0, false
+	}
+	return q.origin.DistanceKm(coordinates), true
+}
+
+// matches reports whether the restaurant passes all filters
+func (q *RestaurantQuery) matches(r Restaurant) bool {
+	for _, filter := range q.filters {
+		if !filter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortKey returns the value the restaurant is sorted by ascending, ok is false if it is unknown
+func (q *RestaurantQuery) sortKey(r Restaurant) (float64, bool) {
+	switch q.sort {
+	case SortByETA:
+		if r.Eta == (EtaRange{}) {
+			return 0, false
+		}
+		return r.Eta.Min.Minutes(), true
+	case SortByRating:
+		return -r.Rating().Stars, true
+	case SortByDeliveryFee:
+		fee, err := r.DeliveryFee()
+		return float64(fee), err == nil
+	case SortByDistance:
+		return q.distance(r)
+	}
+	return 0, true
+}
+
+// All returns all matching restaurants in the requested order.
+// The sort is stable, restaurants with equal or unknown keys keep the order of the API, unknown keys last.
+func (q *RestaurantQuery) All() []Restaurant {
+	type keyed struct {
+		restaurant Restaurant
+		key        float64
+		known      bool
+	}
+	var matches []keyed
+	for _, restaurant := range q.restaurants {
+		if q.matches(restaurant) {
+			key, known := q.sortKey(restaurant)
+			matches = append(matches, keyed{restaurant: restaurant, key: key, known: known})
+		}
+	}
+	if q.sort != SortDefault {
+		slices.SortStableFunc(matches, func(a, b keyed) int {
+			switch {
+			case a.known != b.known:
+				if a.known {
+					return -1
+				}
+				return 1
+			case a.key < b.key:
+				return -1
+			case a.key > b.key:
+				return 1
+			}
+			return 0
+		})
+	}
+	restaurants := make([]Restaurant, 0, len(matches))
+	for _, match := range matches {
+		restaurants = append(restaurants, match.restaurant)
+	}
+	return restaurants
+}
+
+// Page returns the given page of the result, pages start at 1
+func (q *RestaurantQuery) Page(page int, pageSize int) RestaurantPage {
+	all := q.All()
+	if pageSize <= 0 {
+		pageSize = max(len(all), 1)
+	}
+	page = max(page, 1)
+	result := RestaurantPage{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      len(all),
+		TotalPages: (len(all) + pageSize - 1) / pageSize,
+	}
+	start := min((page-1)*pageSize, len(all))
+	end := min(start+pageSize, len(all))
+	result.Restaurants = all[start:end]
+	return result
+}
@@ -0,0 +1,114 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testRestaurantsResponse = `{"rs":{"cp":{"ptd":"90461"},"ct":"2024-01-01 12:00:00","rt":[
+	{"id":"A","nm":"Pizza A","new":0,"eta":{"min":30,"max":45},"cs":{"ct":[["1","Pizza"],["2","Italienisch"]]},
+		"dm":{"dl":{"op":1},"pu":{"op":1}},"sr":{"s4":4.5},"dc":{"ma":"10.00","co":[{"fr":"0","to":"20.00","ct":"2.00"}]},"ad":{"lt":"49.43","ln":"11.08"}},
+	{"id":"B","nm":"Sushi B","new":1,"eta":{"min":20,"max":30},"cs":{"ct":[["3","Sushi"]]},
+		"dm":{"dl":{"op":2},"pu":{"op":0}},"sr":{"s4":"4.8"},"dc":{"ma":"15.00","co":[{"fr":"0","to":"0","ct":"0"}]},"ad":{"lt":"49.45","ln":"11.10"}},
+	{"id":"C","nm":"Pizza C","new":1,"eta":{"min":40,"max":60},"cs":{"ct":[["1","Pizza"]]},
+		"dm":{"dl":{"op":0},"pu":{"op":1}},"sr":{"s4":3.9},"dc":{"ma":"8.00","co":[{"fr":"0","to":"0","ct":"3.50"}]},"ad":{"lt":"48.14","ln":"11.58"}},
+	{"id":"D","nm":"Burger D","new":0,"cs":{"ct":[["4","Burger"]]},
+		"dm":{"dl":{"op":1},"pu":{"op":0}},"sr":{"s4":null},"dc":{"ma":"12.00"}}
+]}}`
+
+func testRestaurants(t *testing.T) RestaurantsResponse {
+	t.Helper()
+	var response restaurantsResponseOuter
+	if err := json.Unmarshal([]byte(testRestaurantsResponse), &response); err != nil {
+		t.Fatalf(`Failed to decode test restaurants: %v`, err)
+	}
+	return response.RestaurantsResponse
+}
+
+func restaurantIDs(restaurants []Restaurant) []string {
+	ids := make([]string, 0, len(restaurants))
+	for _, restaurant := range restaurants {
+		ids = append(ids, restaurant.ID)
+	}
+	return ids
+}
+
+func TestRestaurantQueryFilters(t *testing.T) {
+	restaurants := testRestaurants(t)
+	office := Coordinates{Latitude: 49.44, Longitude: 11.09}
+	tests := map[string]struct {
+		query    *RestaurantQuery
+		expected []string
+	}{
+		"cuisine":       {NewRestaurantQuery(restaurants).Cuisine("pizza"), []string{"A", "C"}},
+		"open now":      {NewRestaurantQuery(restaurants).OpenNow(), []string{"A", "C", "D"}},
+		"delivery":      {NewRestaurantQuery(restaurants).Delivery(), []string{"A", "B", "D"}},
+		"pickup":        {NewRestaurantQuery(restaurants).Pickup(), []string{"A", "C"}},
+		"minimum order": {NewRestaurantQuery(restaurants).MinimumOrderBelow(1200), []string{"A", "C"}},
+		"delivery fee":  {NewRestaurantQuery(restaurants).DeliveryFeeBelow(300), []string{"A", "B"}},
+		"rating":        {NewRestaurantQuery(restaurants).RatingAbove(4), []string{"A", "B"}},
+		"new":           {NewRestaurantQuery(restaurants).New(), []string{"B", "C"}},
+		"distance":      {NewRestaurantQuery(restaurants).Within(office, 5), []string{"A", "B"}},
+		"combined":      {NewRestaurantQuery(restaurants).Cuisine("Pizza").Delivery().New(), []string{}},
+	}
+	for name, test := range tests {
+		ids := restaurantIDs(test.query.All())
+		if len(ids) != len(test.expected) {
+			t.Fatalf(`%s filter returned %v, expected %v`, name, ids, test.expected)
+		}
+		for i := range ids {
+			if ids[i] != test.expected[i] {
+				t.Fatalf(`%s filter returned %v, expected %v`, name, ids, test.expected)
+			}
+		}
+	}
+}
+
+func TestRestaurantQuerySort(t *testing.T) {
+	restaurants := testRestaurants(t)
+	tests := map[RestaurantSort][]string{
+		SortByETA:         {"B", "A", "C", "D"},
+		SortByRating:      {"B", "A", "C", "D"},
+		SortByDeliveryFee: {"B", "A", "C", "D"},
+		SortByDistance:    {"A", "B", "C", "D"},
+	}
+	for sort, expected := range tests {
+		ids := restaurantIDs(NewRestaurantQuery(restaurants).From(Coordinates{Latitude: 49.43, Longitude: 11.08}).SortBy(sort).All())
+		for i := range expected {
+			if ids[i] != expected[i] {
+				t.Fatalf(`Sort %v returned %v, expected %v`, sort, ids, expected)
+			}
+		}
+	}
+
+	page := NewRestaurantQuery(restaurants).SortBy(SortByETA).Page(2, 3)
+	if page.Total != 4 || page.TotalPages != 2 || len(page.Restaurants) != 1 || page.Restaurants[0].ID != "D" {
+		t.Fatalf(`Page returned wrong page: %+v`, page)
+	}
+	if len(NewRestaurantQuery(restaurants).Page(3, 3).Restaurants) != 0 {
+		t.Fatalf(`Page beyond the result must be empty`)
+	}
+}
+
+func TestRestaurantDeliveryFee(t *testing.T) {
+	tests := map[string]struct {
+		dc      string
+		want    Money
+		wantErr bool
+	}{
+		"matching tier": {`{"ma":"10.00","co":[{"fr":"0","to":"20.00","ct":"2.00"}]}`, 200, false},
+		"open ended":    {`{"ma":"15.00","co":[{"fr":"0","to":"0","ct":"0"}]}`, 0, false},
+		"no tiers":      {`{"ma":"12.00"}`, 0, true},
+		"no match":      {`{"ma":"12.00","co":[{"fr":"15.00","to":"0","ct":"1.00"}]}`, 0, true},
+	}
+	for name, test := range tests {
+		var restaurant Restaurant
+		if err := json.Unmarshal([]byte(`{"dc":`+test.dc+`}`), &restaurant); err != nil {
+			t.Fatalf(`%s: failed to decode restaurant: %v`, name, err)
+		}
+		got, err := restaurant.DeliveryFee()
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf(`%s: DeliveryFee() = %v, %v, want %v (error %v)`, name, got, err, test.want, test.wantErr)
+		}
+	}
+}
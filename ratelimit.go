@@ -0,0 +1,75 @@
+package takeawayapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the requests a client sends to the API.
+// Wait blocks until the next request may be sent or ctx is done.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a RateLimiter allowing bursts of up to burst requests
+// and refilling at requestsPerSecond
+type TokenBucket struct {
+	interval time.Duration
+	burst    float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a TokenBucket allowing requestsPerSecond requests on average
+// and bursts of up to burst requests. A requestsPerSecond of 0 or less means no limit.
+func NewRateLimiter(requestsPerSecond float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	tb := &TokenBucket{
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+	if requestsPerSecond > 0 {
+		tb.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return tb
+}
+
+// Wait takes a token from the bucket, waiting until one is available or ctx is done
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait := tb.take()
+		if wait == 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take removes a token if one is available and otherwise returns the time until the next one
+func (tb *TokenBucket) take() time.Duration {
+	if tb.interval <= 0 {
+		return 0
+	}
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	now := time.Now()
+	tb.tokens = min(tb.burst, tb.tokens+float64(now.Sub(tb.last))/float64(tb.interval))
+	tb.last = now
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return 0
+	}
+	return time.Duration((1 - tb.tokens) * float64(tb.interval))
+}
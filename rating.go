@@ -0,0 +1,50 @@
+package takeawayapi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Rating is the customer rating and ranking signals of a restaurant.
+// The API doesn't document its score fields, so the mapping below is provisional: it is a guess
+// which isn't backed by fixtures or documentation of the API and may change.
+type Rating struct {
+	// Stars is the average customer rating from 0 to 5 ("sr.s4" in the listing, "rt.cr" in the restaurant data)
+	Stars float64
+	// ReviewCount is the number of reviews the rating is based on ("rv" in the listing, "oo.rv" in the restaurant data)
+	ReviewCount int
+	// Popularity is higher for restaurants which are ordered from more often
+	// ("sr.s1" in the listing, "rt.prr" in the restaurant data)
+	Popularity float64
+	// Ranking is the position signal the API uses to sort restaurants, higher ranks first ("sr.s2" in the listing)
+	Ranking float64
+}
+
+// parseCount parses a count sent as string, unparseable counts are zero
+func parseCount(count string) int {
+	value, err := strconv.ParseFloat(strings.TrimSpace(count), 64)
+	if err != nil {
+		return 0
+	}
+	return int(value)
+}
+
+// Rating maps the score block of the restaurant listing to a Rating, see Rating for the provisional field mapping
+func (r Restaurant) Rating() Rating {
+	return Rating{
+		Stars:       float64(r.Sr.S4),
+		ReviewCount: parseCount(r.Rv),
+		Popularity:  float64(r.Sr.S1),
+		Ranking:     float64(r.Sr.S2),
+	}
+}
+
+// Rating maps the rating block of the restaurant data to a Rating, see Rating for the provisional field mapping.
+// The restaurant data has no ranking, Ranking is always zero.
+func (rd RestaurantData) Rating() Rating {
+	return Rating{
+		Stars:       float64(rd.Rt.Cr),
+		ReviewCount: parseCount(rd.Oo.Rv),
+		Popularity:  float64(rd.Rt.Prr),
+	}
+}
@@ -0,0 +1,32 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRestaurantRating(t *testing.T) {
+	var restaurant Restaurant
+	data := `{"id":"A","rv":"123","sr":{"s1":"17","s2":42,"s3":null,"s4":"4,5","s5":"","s6":true,"s7":1.5,"s8":0,"s9":"0","s12":null}}`
+	if err := json.Unmarshal([]byte(data), &restaurant); err != nil {
+		t.Fatalf(`Failed to decode restaurant: %v`, err)
+	}
+	rating := restaurant.Rating()
+	if rating.Stars != 4.5 || rating.ReviewCount != 123 || rating.Popularity != 17 || rating.Ranking != 42 {
+		t.Fatalf(`Restaurant rating decoded wrong: %+v`, rating)
+	}
+
+	var restaurantData RestaurantData
+	if err := json.Unmarshal([]byte(`{"ri":"A","oo":{"rv":"80"},"rt":{"cr":"3.8","prr":null}}`), &restaurantData); err != nil {
+		t.Fatalf(`Failed to decode restaurant data: %v`, err)
+	}
+	rating = restaurantData.Rating()
+	if rating.Stars != 3.8 || rating.ReviewCount != 80 || rating.Popularity != 0 {
+		t.Fatalf(`Restaurant data rating decoded wrong: %+v`, rating)
+	}
+
+	var invalid FlexFloat
+	if err := json.Unmarshal([]byte(`"abc"`), &invalid); err == nil {
+		t.Fatalf(`FlexFloat accepted a non numeric string`)
+	}
+}
@@ -0,0 +1,167 @@
+package takeawayapi
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// countryURL is the API URL of a country, filled with Country.APIDomain
+const countryURL = "https://%s/android/android.php"
+
+// countryCodes maps the two letter country codes sent by the API ("cy") to their CountryCode
+var countryCodes = map[string]CountryCode{
+	"NL": NL,
+	"DE": DE,
+	"BE": BE,
+	"AT": AT,
+	"CH": CH,
+	"LU": LU,
+	"PL": PL,
+	"PT": PT,
+	"VN": VN,
+}
+
+// ParseCountryCode returns the CountryCode of a two letter country code like "DE", ignoring case
+func ParseCountryCode(code string) (CountryCode, error) {
+	cc, ok := countryCodes[strings.ToUpper(code)]
+	if !ok {
+		return 0, fmt.Errorf("unknown country code %q", code)
+	}
+	return cc, nil
+}
+
+// RegistryOptions configures a Registry
+type RegistryOptions struct {
+	// Languages selects the language per country, defaults to the first language the country supports (Country.Ls)
+	Languages map[CountryCode]string
+}
+
+// Registry manages one client per country and routes requests by CountryCode.
+// The clients are built lazily from GetCountriesData and share the HTTP client, headers, rate limiter,
+// response cache and logger of the base client. Sessions are not shared, every country logs in on its own.
+// It is safe for concurrent use.
+type Registry struct {
+	base *TakeAwayClient
+	opts RegistryOptions
+
+	mu        sync.Mutex
+	countries map[CountryCode]Country
+	clients   map[CountryCode]*TakeAwayClient
+}
+
+// NewRegistry creates a registry, base is used to request the available countries and as template for all clients
+func NewRegistry(base *TakeAwayClient, opts RegistryOptions) *Registry {
+	return &Registry{base: base, opts: opts, clients: map[CountryCode]*TakeAwayClient{}}
+}
+
+// loadCountries requests the available countries once, r.mu must be held
+func (r *Registry) loadCountries() error {
+	if r.countries != nil {
+		return nil
+	}
+	available, err := r.base.GetCountriesData()
+	if err != nil {
+		return fmt.Errorf("error loading countries: %w", err)
+	}
+	countries := map[CountryCode]Country{}
+	for _, country := range available.CountryData {
+		cc, err := ParseCountryCode(country.CountryA2)
+		if err != nil {
+			// Countries this package doesn't know can't be requested by CountryCode anyway
+			continue
+		}
+		country.InternalCountryCode = cc
+		countries[cc] = country
+	}
+	r.countries = countries
+	return nil
+}
+
+// Countries returns the available countries by CountryCode
+func (r *Registry) Countries() (map[CountryCode]Country, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.loadCountries(); err != nil {
+		return nil, err
+	}
+	return maps.Clone(r.countries), nil
+}
+
+// Client returns the client of the country, building it on first use
+func (r *Registry) Client(cc CountryCode) (*TakeAwayClient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if client, ok := r.clients[cc]; ok {
+		return client, nil
+	}
+	if err := r.loadCountries(); err != nil {
+		return nil, err
+	}
+	country, ok := r.countries[cc]
+	if !ok {
+		return nil, fmt.Errorf("country %d is not available", cc)
+	}
+	if country.APIDomain == "" {
+		return nil, fmt.Errorf("country %s has no API domain", country.CountryA2)
+	}
+
+	client := *r.base
+	client.BaseURL = fmt.Sprintf(countryURL, country.APIDomain)
+	if strings.Contains(country.APIDomain, "://") {
+		client.BaseURL = strings.TrimSuffix(country.APIDomain, "/") + "/android/android.php"
+	}
+	client.Language = r.language(country)
+	client.Headers = maps.Clone(r.base.Headers)
+	client.auth = nil
+	r.clients[cc] = &client
+	return &client, nil
+}
+
+// language returns the language used for the country
+func (r *Registry) language(country Country) string {
+	if language, ok := r.opts.Languages[country.InternalCountryCode]; ok {
+		return language
+	}
+	if len(country.Ls.La) > 0 {
+		return country.Ls.La[0]
+	}
+	return r.base.Language
+}
+
+// GetRestaurants returns the restaurants using the client of the country
+func (r *Registry) GetRestaurants(postalCode string, cc CountryCode, latitude string, longitude string) (RestaurantsResponse, error) {
+	client, err := r.Client(cc)
+	if err != nil {
+		return RestaurantsResponse{}, err
+	}
+	return client.GetRestaurants(postalCode, cc, latitude, longitude)
+}
+
+// GetRestaurantData returns the data of a restaurant using the client of the country
+func (r *Registry) GetRestaurantData(restaurantID string, postcode string, cc CountryCode, latitude string, longitude string, clientID string) (RestaurantData, error) {
+	client, err := r.Client(cc)
+	if err != nil {
+		return RestaurantData{}, err
+	}
+	return client.GetRestaurantData(restaurantID, postcode, cc, latitude, longitude, clientID)
+}
+
+// GetRestaurantCheckoutData returns the checkout data of a restaurant using the client of the country
+func (r *Registry) GetRestaurantCheckoutData(restaurantID string, postcode string, cc CountryCode, latitude string, longitude string, clientID string) (RestaurantData, error) {
+	client, err := r.Client(cc)
+	if err != nil {
+		return RestaurantData{}, err
+	}
+	return client.GetRestaurantCheckoutData(restaurantID, postcode, cc, latitude, longitude, clientID)
+}
+
+// GetCurrentTime returns the current time using the client of the country
+func (r *Registry) GetCurrentTime(cc CountryCode, restaurantID string, orderingMode int) (CurrentTimeResponse, error) {
+	client, err := r.Client(cc)
+	if err != nil {
+		return CurrentTimeResponse{}, err
+	}
+	return client.GetCurrentTime(cc, restaurantID, orderingMode)
+}
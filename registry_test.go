@@ -0,0 +1,86 @@
+package takeawayapi
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseCountryCode(t *testing.T) {
+	if cc, err := ParseCountryCode("de"); err != nil || cc != DE {
+		t.Errorf(`Expected DE, got %d: %v`, cc, err)
+	}
+	if _, err := ParseCountryCode("XX"); err == nil {
+		t.Errorf(`Expected an error for an unknown country`)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	_, nlServer := newTestClient(t, map[string]string{"getrestaurants": testRestaurantsResponse})
+	base, ts := newTestClient(t, map[string]string{"getrestaurants": testRestaurantsResponse})
+	ts.SetResponse("getcountriesdata", fmt.Sprintf(`{"av":{"cd":[
+		{"cy":"DE","su":"%s","ls":{"la":["de","en"]}},
+		{"cy":"NL","su":"%s","ls":{"la":["nl","en"]}},
+		{"cy":"AT","ls":{"la":["de"]}},
+		{"cy":"XX","su":"xx.example.com"}
+	]}}`, ts.URL, nlServer.URL))
+	var logs bytes.Buffer
+	base.Logger = slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	base.RateLimiter = NewRateLimiter(1000, 10)
+	base.EnableCache(CacheConfig{})
+	registry := NewRegistry(base, RegistryOptions{Languages: map[CountryCode]string{NL: "en"}})
+
+	de, err := registry.Client(DE)
+	if err != nil {
+		t.Fatalf(`Failed to get client: %v`, err)
+	}
+	if de.BaseURL != ts.URL+"/android/android.php" || de.Language != "de" {
+		t.Errorf(`Unexpected client for DE: %s %s`, de.BaseURL, de.Language)
+	}
+	if de.RateLimiter != base.RateLimiter || de.cache != base.cache || de.Logger != base.Logger {
+		t.Errorf(`Expected the client to share rate limiter, cache and logger with the base client`)
+	}
+	de.SetHeader("X-Country", "DE")
+	if _, ok := base.Headers["X-Country"]; ok {
+		t.Errorf(`Expected headers to be copied`)
+	}
+	if again, _ := registry.Client(DE); again != de {
+		t.Errorf(`Expected the client to be reused`)
+	}
+
+	if _, err := registry.GetRestaurants("1012", NL, "", ""); err != nil {
+		t.Fatalf(`Failed to get restaurants: %v`, err)
+	}
+	if nlServer.Hits("getrestaurants") != 1 || ts.Hits("getrestaurants") != 0 {
+		t.Errorf(`Expected the request to be routed to the NL server`)
+	}
+	if form := nlServer.LastForm("getrestaurants"); form.Get("var6") != "en" {
+		t.Errorf(`Expected language en for NL, got %q`, form.Get("var6"))
+	}
+	nlServer.SetResponse("getrestaurantdata", `{"rd":{"nm":"Pizza Test","ri":"R1","ct":"2024-01-01 12:00:00"}}`)
+	if _, err := registry.GetRestaurantData("R1", "1012", NL, "", "", ""); err != nil {
+		t.Fatalf(`Failed to get restaurant data: %v`, err)
+	}
+	if form := nlServer.LastForm("getrestaurantdata"); form.Get("language") != "en" {
+		t.Errorf(`Expected language parameter en for NL, got %q`, form.Get("language"))
+	}
+	if !strings.Contains(logs.String(), "function=getrestaurants") {
+		t.Errorf(`Expected the request to be logged, got %q`, logs.String())
+	}
+
+	if _, err := registry.Client(AT); err == nil || !strings.Contains(err.Error(), "no API domain") {
+		t.Errorf(`Expected an error for a country without API domain, got %v`, err)
+	}
+	if _, err := registry.Client(PL); err == nil || !strings.Contains(err.Error(), "not available") {
+		t.Errorf(`Expected an error for an unavailable country, got %v`, err)
+	}
+	countries, err := registry.Countries()
+	if err != nil || len(countries) != 3 || countries[NL].InternalCountryCode != NL {
+		t.Errorf(`Unexpected countries %v: %v`, countries, err)
+	}
+	if hits := ts.Hits("getcountriesdata"); hits != 1 {
+		t.Errorf(`Expected the countries to be requested once, got %d`, hits)
+	}
+}
@@ -0,0 +1,292 @@
+package takeawayapi
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Field weights of the menu index, a match in the product name counts most
+const (
+	weightName        = 3.0
+	weightCategory    = 2.0
+	weightDescription = 1.0
+	weightSideDish    = 0.5
+)
+
+// Match factors for query tokens which only match the start or the inside of an indexed token,
+// the latter finds parts of compound words like "suppe" in "nudelsuppe"
+const (
+	prefixMatchFactor = 0.7
+	infixMatchFactor  = 0.5
+	minInfixLength    = 4
+)
+
+// foldReplacer removes the diacritics of German, Dutch and Polish
+var foldReplacer = strings.NewReplacer(
+	"ä", "a", "ö", "o", "ü", "u", "ß", "ss",
+	"á", "a", "à", "a", "â", "a", "é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i", "ó", "o", "ò", "o", "ô", "o", "ú", "u", "ù", "u", "û", "u",
+	"ĳ", "ij",
+	"ą", "a", "ć", "c", "ę", "e", "ł", "l", "ń", "n", "ś", "s", "ź", "z", "ż", "z",
+)
+
+// stopwords of German, Dutch and Polish which are not indexed
+var stopwords = map[string]bool{
+	// German
+	"der": true, "die": true, "das": true, "und": true, "mit": true, "oder": true, "ein": true, "eine": true, "von": true, "aus": true, "auf": true, "im": true, "in": true,
+	// Dutch
+	"de": true, "het": true, "een": true, "en": true, "met": true, "of": true, "van": true, "op": true,
+	// Polish
+	"i": true, "z": true, "ze": true, "w": true, "na": true, "lub": true, "oraz": true, "do": true,
+}
+
+// foldText lowercases text and removes diacritics
+func foldText(text string) string {
+	return foldReplacer.Replace(strings.ToLower(text))
+}
+
+// tokenize splits text into folded tokens without stopwords
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(foldText(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := fields[:0]
+	for _, field := range fields {
+		if !stopwords[field] {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+// menuDocument is an indexed product
+type menuDocument struct {
+	restaurantID   string
+	restaurantName string
+	category       string
+	product        Product
+}
+
+// MenuIndex is an in-memory full-text index over the menus of restaurants.
+// It is safe for concurrent use.
+type MenuIndex struct {
+	mu           sync.RWMutex
+	nextID       int
+	documents    map[int]menuDocument
+	byRestaurant map[string][]int
+	// postings maps a token to the weight it has in each document
+	postings map[string]map[int]float64
+}
+
+// SearchOptions filters the results of MenuIndex.Search
+type SearchOptions struct {
+	// Mode selects the pickup or delivery price, defaults to delivery
+	Mode OrderMode
+	// MaxPrice only returns products costing at most this amount, zero means no limit
+	MaxPrice Money
+	// ExcludeAllergens leaves out products listing any of these allergens (Product.Fai.All)
+	ExcludeAllergens []string
+	// RestaurantIDs limits the search to these restaurants
+	RestaurantIDs []string
+	// Limit is the maximum number of results, zero means no limit
+	Limit int
+}
+
+// SearchResult is a product matching a search
+type SearchResult struct {
+	RestaurantID   string
+	RestaurantName string
+	Category       string
+	Product        Product
+	Price          Money
+	Score          float64
+}
+
+// NewMenuIndex creates an index over the menus of the restaurants
+func NewMenuIndex(restaurants ...RestaurantData) *MenuIndex {
+	index := &MenuIndex{
+		documents:    map[int]menuDocument{},
+		byRestaurant: map[string][]int{},
+		postings:     map[string]map[int]float64{},
+	}
+	for _, restaurant := range restaurants {
+		index.Update(restaurant)
+	}
+	return index
+}
+
+// Update replaces the indexed menu of the restaurant by its current menu
+func (mi *MenuIndex) Update(restaurant RestaurantData) {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	mi.remove(restaurant.RestaurantID)
+	for _, category := range restaurant.Menu.CategorieStruct.Categories {
+		for _, product := range category.ProductStruct.Products {
+			id := mi.nextID
+			mi.nextID++
+			mi.documents[id] = menuDocument{
+				restaurantID:   restaurant.RestaurantID,
+				restaurantName: restaurant.Name,
+				category:       category.Name,
+				product:        product,
+			}
+			mi.byRestaurant[restaurant.RestaurantID] = append(mi.byRestaurant[restaurant.RestaurantID], id)
+
+			mi.addTokens(id, product.Name, weightName)
+			mi.addTokens(id, category.Name, weightCategory)
+			mi.addTokens(id, product.Description, weightDescription)
+			for _, sideDish := range product.SideItems.SideDishes {
+				for _, choice := range sideDish.Cc.Ch {
+					mi.addTokens(id, choice.Name, weightSideDish)
+				}
+			}
+		}
+	}
+}
+
+// Remove removes the menu of the restaurant from the index
+func (mi *MenuIndex) Remove(restaurantID string) {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	mi.remove(restaurantID)
+}
+
+// Len returns the number of indexed products
+func (mi *MenuIndex) Len() int {
+	mi.mu.RLock()
+	defer mi.mu.RUnlock()
+	return len(mi.documents)
+}
+
+// remove removes the documents of the restaurant, mi.mu must be held
+func (mi *MenuIndex) remove(restaurantID string) {
+	ids := mi.byRestaurant[restaurantID]
+	if len(ids) == 0 {
+		return
+	}
+	removed := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+		delete(mi.documents, id)
+	}
+	delete(mi.byRestaurant, restaurantID)
+	for token, documents := range mi.postings {
+		for id := range documents {
+			if removed[id] {
+				delete(documents, id)
+			}
+		}
+		if len(documents) == 0 {
+			delete(mi.postings, token)
+		}
+	}
+}
+
+// addTokens indexes text for the document, a token keeps the highest weight of its fields
+func (mi *MenuIndex) addTokens(id int, text string, weight float64) {
+	for _, token := range tokenize(text) {
+		documents, ok := mi.postings[token]
+		if !ok {
+			documents = map[int]float64{}
+			mi.postings[token] = documents
+		}
+		documents[id] = max(documents[id], weight)
+	}
+}
+
+// matchToken returns the score of every document for a single query token
+func (mi *MenuIndex) matchToken(queryToken string) map[int]float64 {
+	scores := map[int]float64{}
+	for token, documents := range mi.postings {
+		factor := 0.0
+		switch {
+		case token == queryToken:
+			factor = 1
+		case strings.HasPrefix(token, queryToken):
+			factor = prefixMatchFactor
+		case len(queryToken) >= minInfixLength && strings.Contains(token, queryToken):
+			factor = infixMatchFactor
+		default:
+			continue
+		}
+		for id, weight := range documents {
+			scores[id] = max(scores[id], weight*factor)
+		}
+	}
+	return scores
+}
+
+// Search returns the products matching all words of the query, the best match first.
+// Results with the same score are ordered by price.
+func (mi *MenuIndex) Search(query string, opts SearchOptions) []SearchResult {
+	tokens := tokenize(query)
+	if len(tokens) == 0 {
+		return nil
+	}
+	mode := opts.Mode
+	if mode == 0 {
+		mode = OrderModeDelivery
+	}
+
+	mi.mu.RLock()
+	defer mi.mu.RUnlock()
+
+	var scores map[int]float64
+	for _, token := range tokens {
+		tokenScores := mi.matchToken(token)
+		if scores == nil {
+			scores = tokenScores
+			continue
+		}
+		// Every query token has to match
+		for id := range scores {
+			if tokenScore, ok := tokenScores[id]; ok {
+				scores[id] += tokenScore
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	var results []SearchResult
+	for id, score := range scores {
+		document := mi.documents[id]
+		if len(opts.RestaurantIDs) > 0 && !slices.Contains(opts.RestaurantIDs, document.restaurantID) {
+			continue
+		}
+		if slices.ContainsFunc(document.product.Fai.All, func(allergen string) bool {
+			return slices.Contains(opts.ExcludeAllergens, allergen)
+		}) {
+			continue
+		}
+		price, err := document.product.Price(mode)
+		if opts.MaxPrice > 0 && (err != nil || price > opts.MaxPrice) {
+			continue
+		}
+		results = append(results, SearchResult{
+			RestaurantID:   document.restaurantID,
+			RestaurantName: document.restaurantName,
+			Category:       document.category,
+			Product:        document.product,
+			Price:          price,
+			Score:          score,
+		})
+	}
+	slices.SortFunc(results, func(a, b SearchResult) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		case a.Price != b.Price:
+			return int(a.Price - b.Price)
+		}
+		return strings.Compare(a.Product.ID, b.Product.ID)
+	})
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results
+}
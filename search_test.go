@@ -0,0 +1,171 @@
+package takeawayapi
+
+import (
+	"slices"
+	"testing"
+)
+
+func searchProductIDs(results []SearchResult) []string {
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.Product.ID)
+	}
+	return ids
+}
+
+func TestTokenize(t *testing.T) {
+	tests := map[string][]string{
+		"Pizza mit Käse":        {"pizza", "kase"},
+		"Żurek z kiełbasą":      {"zurek", "kielbasa"},
+		"Patat met mayonaise":   {"patat", "mayonaise"},
+		"Großer Döner, scharf!": {"grosser", "doner", "scharf"},
+		"  ":                    {},
+	}
+	for text, want := range tests {
+		if got := tokenize(text); !slices.Equal(got, want) {
+			t.Errorf(`tokenize(%q) = %v, want %v`, text, got, want)
+		}
+	}
+}
+
+func TestMenuIndexSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		menu  string
+		query string
+		opts  SearchOptions
+		want  []string
+	}{
+		{
+			name:  "name",
+			menu:  `{"nm":"Nudeln","ps":{"pr":[{"id":"p1","nm":"Ramen","tc":"11.50"},{"id":"p2","nm":"Pho","tc":"10.50"}]}}`,
+			query: "ramen",
+			want:  []string{"p1"},
+		},
+		{
+			name:  "max price",
+			menu:  `{"nm":"Nudeln","ps":{"pr":[{"id":"p1","nm":"Ramen","tc":"11.50"},{"id":"p2","nm":"Ramen XL","tc":"14.00"}]}}`,
+			query: "ramen",
+			opts:  SearchOptions{MaxPrice: 1200},
+			want:  []string{"p1"},
+		},
+		{
+			name:  "pickup price",
+			menu:  `{"nm":"Nudeln","ps":{"pr":[{"id":"p1","nm":"Ramen","pc":"11.00","tc":"11.50"}]}}`,
+			query: "ramen",
+			opts:  SearchOptions{Mode: OrderModePickup, MaxPrice: 1100},
+			want:  []string{"p1"},
+		},
+		{
+			name:  "category",
+			menu:  `{"nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","tc":"8.00"},{"id":"p2","nm":"Salami","tc":"9.00"}]}}`,
+			query: "pizza",
+			want:  []string{"p1", "p2"},
+		},
+		{
+			name:  "diacritics",
+			menu:  `{"nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","ds":"Tomaten, Käse"},{"id":"p2","nm":"Marinara","ds":"Tomaten"}]}}`,
+			query: "kase",
+			want:  []string{"p1"},
+		},
+		{
+			name:  "compound",
+			menu:  `{"nm":"Suppen","ps":{"pr":[{"id":"p1","nm":"Nudelsuppe"}]}}`,
+			query: "suppe",
+			want:  []string{"p1"},
+		},
+		{
+			name:  "prefix",
+			menu:  `{"nm":"Suppen","ps":{"pr":[{"id":"p1","nm":"Nudelsuppe"}]}}`,
+			query: "nudel",
+			want:  []string{"p1"},
+		},
+		{
+			name:  "all words",
+			menu:  `{"nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Pizza Margherita"},{"id":"p2","nm":"Pizza Salami"}]}}`,
+			query: "pizza salami",
+			want:  []string{"p2"},
+		},
+		{
+			name:  "excluded allergen",
+			menu:  `{"nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Pizza Margherita","fai":{"all":["G"]}},{"id":"p2","nm":"Pizza Salami","fai":{"all":[]}}]}}`,
+			query: "pizza",
+			opts:  SearchOptions{ExcludeAllergens: []string{"G"}},
+			want:  []string{"p2"},
+		},
+		{
+			name:  "other restaurant",
+			menu:  `{"nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Pizza Margherita"}]}}`,
+			query: "pizza",
+			opts:  SearchOptions{RestaurantIDs: []string{"XYZ"}},
+			want:  []string{},
+		},
+		{
+			name:  "limit",
+			menu:  `{"nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Pizza Margherita","tc":"8.00"},{"id":"p2","nm":"Pizza Salami","tc":"9.00"}]}}`,
+			query: "pizza",
+			opts:  SearchOptions{Limit: 1},
+			want:  []string{"p1"},
+		},
+		{
+			name:  "name before description",
+			menu:  `{"nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","ds":"mit Salami"},{"id":"p2","nm":"Salami","tc":"9.00"}]}}`,
+			query: "salami",
+			want:  []string{"p2", "p1"},
+		},
+		{
+			name:  "stopwords only",
+			menu:  `{"nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Pizza mit Käse und Salami"}]}}`,
+			query: "mit und",
+			want:  []string{},
+		},
+	}
+	for _, test := range tests {
+		index := NewMenuIndex(testMenu(t, "R1", test.menu))
+		got := searchProductIDs(index.Search(test.query, test.opts))
+		if !slices.Equal(got, test.want) {
+			t.Errorf(`%s: Search(%q) = %v, want %v`, test.name, test.query, got, test.want)
+		}
+	}
+}
+
+func TestMenuIndexSearchResult(t *testing.T) {
+	restaurant := testMenu(t, "R1", `{"nm":"Pizza","ps":{"pr":[{"id":"p1","nm":"Margherita","pc":"7.50","tc":"8.00"}]}}`)
+	restaurant.Name = "Pizza Test"
+	got := NewMenuIndex(restaurant).Search("margherita", SearchOptions{})
+	if len(got) != 1 {
+		t.Fatalf(`Search() returned %d results, want 1`, len(got))
+	}
+	want := SearchResult{RestaurantID: "R1", RestaurantName: "Pizza Test", Category: "Pizza", Price: 800}
+	if got[0].RestaurantID != want.RestaurantID || got[0].RestaurantName != want.RestaurantName || got[0].Category != want.Category || got[0].Price != want.Price {
+		t.Errorf(`Search() = %+v, want %+v`, got[0], want)
+	}
+}
+
+func TestMenuIndexUpdate(t *testing.T) {
+	ramen := `{"nm":"Nudeln","ps":{"pr":[{"id":"p1","nm":"Ramen"}]}}`
+	pizza := `{"nm":"Pizza","ps":{"pr":[{"id":"p2","nm":"Margherita"}]}}`
+	index := NewMenuIndex(testMenu(t, "R1", ramen), testMenu(t, "R2", ramen, pizza))
+
+	steps := []struct {
+		name     string
+		change   func()
+		wantLen  int
+		wantFrom []string
+	}{
+		{"initial", func() {}, 3, []string{"R1", "R2"}},
+		{"update", func() { index.Update(testMenu(t, "R1", pizza)) }, 3, []string{"R2"}},
+		{"remove", func() { index.Remove("R2") }, 1, []string{}},
+	}
+	for _, step := range steps {
+		step.change()
+		var from []string
+		for _, result := range index.Search("ramen", SearchOptions{}) {
+			from = append(from, result.RestaurantID)
+		}
+		slices.Sort(from)
+		if index.Len() != step.wantLen || !slices.Equal(from, step.wantFrom) {
+			t.Errorf(`%s: Len() = %d, ramen from %v, want %d and %v`, step.name, index.Len(), from, step.wantLen, step.wantFrom)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package takeawayapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testServer answers API functions with canned JSON responses and counts the requests per function
+type testServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses map[string]string
+	handlers  map[string]func(form url.Values) string
+	forms     map[string]url.Values
+	hits      map[string]int
+	delay     time.Duration
+}
+
+// newTestClient starts a testServer and returns a client sending its requests to it
+func newTestClient(t *testing.T, responses map[string]string) (*TakeAwayClient, *testServer) {
+	t.Helper()
+	ts := &testServer{responses: responses, handlers: map[string]func(url.Values) string{}, forms: map[string]url.Values{}, hits: map[string]int{}}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		function := r.PostForm.Get("var1")
+		ts.mu.Lock()
+		ts.hits[function]++
+		ts.forms[function] = r.PostForm
+		response, ok := ts.responses[function]
+		if handler, found := ts.handlers[function]; found {
+			response, ok = handler(r.PostForm), true
+		}
+		delay := ts.delay
+		ts.mu.Unlock()
+		time.Sleep(delay)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(ts.Close)
+
+	tac := NewClientWithHTTPClient("de", ts.Client())
+	tac.BaseURL = ts.URL
+	return tac, ts
+}
+
+// Hits returns how often function was requested
+func (ts *testServer) Hits(function string) int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.hits[function]
+}
+
+// SetResponse changes the canned response for function
+func (ts *testServer) SetResponse(function string, response string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.responses[function] = response
+}
+
+// SetHandler answers function with the response computed from the request form
+func (ts *testServer) SetHandler(function string, handler func(form url.Values) string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.handlers[function] = handler
+}
+
+// LastForm returns the form of the last request for function
+func (ts *testServer) LastForm(function string) url.Values {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.forms[function]
+}
+
+// SetDelay makes the server wait before answering each request
+func (ts *testServer) SetDelay(delay time.Duration) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.delay = delay
+}
+
+// testMenuResponse is a getrestaurantdata response with a small menu
+const testMenuResponse = `{"rd":{"nm":"Pizza Test","ri":"O3QQ11PN","ct":"2024-01-01 12:00:00",
+	"dc":{"ma":"10.00"},
+	"dd":{"da":[{"pc":{"pp":["90461","90459"]},"ma":"12.00","co":[{"fr":"0.00","to":"20.00","ct":"2.50"},{"fr":"20.00","to":"0","ct":"0.00"}]}]},
+	"pm":{"me":[{"mi":"0","mt":"Bar","mf":"0"}]},
+	"dt":{"td":{"ti":[]},"tm":{"ti":[]}},
+	"mc":{"cs":{"ct":[
+		{"id":"c1","nm":"Pizza","ds":"Aus dem Steinofen","ps":{"pr":[
+			{"id":"p1","nm":"Pizza Margherita","ds":"Tomaten, Käse","pc":"7.50","tc":"8,00","fai":{"all":["A","G"],"add":[],"xtr":[]},
+				"ss":{"sd":[{"nm":"Extras","cc":{"ch":[{"id":"s1","nm":"Extra Käse","pc":"1.00","tc":"1.00"}]},"tp":"1"}]}},
+			{"id":"p2","nm":"Pizza Salami","pc":"8.50","tc":"9.00","fai":{"all":[]}}]}},
+		{"id":"c2","nm":"Nudeln","ps":{"pr":{"id":"p3","nm":"Ramen","ds":"Japanische Nudelsuppe","pc":"11.00","tc":"11.50","fai":{"all":{"id":["F"]}}}}}
+	]}}}}`
+
+// testMenu returns a restaurant with a menu of the given categories, each a JSON object like the API sends them
+func testMenu(t *testing.T, restaurantID string, categories ...string) RestaurantData {
+	t.Helper()
+	var restaurant RestaurantData
+	data := fmt.Sprintf(`{"ri":%q,"mc":{"cs":{"ct":[%s]}}}`, restaurantID, strings.Join(categories, ","))
+	if err := json.Unmarshal([]byte(data), &restaurant); err != nil {
+		t.Fatalf(`Failed to decode test menu: %v`, err)
+	}
+	return restaurant
+}
+
+// testRestaurantData decodes testMenuResponse
+func testRestaurantData(t *testing.T) RestaurantData {
+	t.Helper()
+	var response restaurantDataResponse
+	if err := json.Unmarshal([]byte(testMenuResponse), &response); err != nil {
+		t.Fatalf(`Failed to decode test menu: %v`, err)
+	}
+	return response.RestaurantData
+}
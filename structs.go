@@ -177,50 +177,38 @@ type RestaurantsResponse struct {
 }
 
 type Restaurant struct {
-	ID         string `json:"id"`
-	Pcid       string `json:"pcid"`
-	Name       string `json:"nm"`
-	Branchname string `json:"bn"`
-	Op         string `json:"op"`
-	Hd         string `json:"hd"`
-	Dm         struct {
-		Ah any `json:"ah"`
-		Dl struct {
-			Op int    `json:"op"`
-			Oh string `json:"oh"`
-		} `json:"dl"`
-		Pu struct {
-			Op int    `json:"op"`
-			Oh string `json:"oh"`
-		} `json:"pu"`
-	} `json:"dm"`
-	Tip           int `json:"tip"`
-	New           int `json:"new"`
-	Ply           int `json:"ply"`
-	EstimatedTime any `json:"est"`
-	Eta           struct {
-		Min int `json:"min"`
-		Max int `json:"max"`
-	} `json:"eta"`
-	Ft             string `json:"ft"`
-	Ck             string `json:"ck"`
-	Ds             string `json:"ds"`
-	Logo           string `json:"lo"`
-	CloudinaryLogo string `json:"cloudinaryLogo"`
+	ID             string        `json:"id"`
+	Pcid           string        `json:"pcid"`
+	Name           string        `json:"nm"`
+	Branchname     string        `json:"bn"`
+	Op             string        `json:"op"`
+	Hd             string        `json:"hd"`
+	Dm             DeliveryModes `json:"dm"`
+	Tip            int           `json:"tip"`
+	New            int           `json:"new"`
+	Ply            int           `json:"ply"`
+	EstimatedTime  any           `json:"est"`
+	Eta            EtaRange      `json:"eta"`
+	Ft             string        `json:"ft"`
+	Ck             string        `json:"ck"`
+	Ds             string        `json:"ds"`
+	Logo           string        `json:"lo"`
+	CloudinaryLogo string        `json:"cloudinaryLogo"`
 	Cs             struct {
 		Ct [][]string `json:"ct"`
 	} `json:"cs"`
+	// Sr are the scores used for ranking, see Restaurant.Rating
 	Sr struct {
-		S1  int     `json:"s1"`
-		S2  int     `json:"s2"`
-		S3  any     `json:"s3"`
-		S4  float64 `json:"s4"`
-		S5  any     `json:"s5"`
-		S6  any     `json:"s6"`
-		S7  any     `json:"s7"`
-		S8  any     `json:"s8"`
-		S9  any     `json:"s9"`
-		S12 any     `json:"s12"`
+		S1  FlexFloat `json:"s1"`
+		S2  FlexFloat `json:"s2"`
+		S3  FlexFloat `json:"s3"`
+		S4  FlexFloat `json:"s4"`
+		S5  FlexFloat `json:"s5"`
+		S6  FlexFloat `json:"s6"`
+		S7  FlexFloat `json:"s7"`
+		S8  FlexFloat `json:"s8"`
+		S9  FlexFloat `json:"s9"`
+		S12 FlexFloat `json:"s12"`
 	} `json:"sr"`
 	Nt           string `json:"nt"`
 	ChainPenalty bool   `json:"chain_penalty"`
@@ -240,13 +228,9 @@ type Restaurant struct {
 		} `json:"me"`
 	} `json:"pm"`
 	Dc struct {
-		Ma string `json:"ma"`
-		Co []struct {
-			Fr string `json:"fr"`
-			To string `json:"to"`
-			Ct string `json:"ct"`
-		} `json:"co"`
-		Ddf []interface{} `json:"ddf"`
+		Ma  string         `json:"ma"`
+		Co  []DeliveryCost `json:"co"`
+		Ddf []interface{}  `json:"ddf"`
 	} `json:"dc"`
 	Rv      string  `json:"rv"`
 	Rvd     string  `json:"rvd"`
@@ -254,6 +238,13 @@ type Restaurant struct {
 	Bd      string  `json:"bd"`
 }
 
+// DeliveryCost is a delivery cost tier: orders from Fr up to To cost Ct
+type DeliveryCost struct {
+	Fr string `json:"fr"`
+	To string `json:"to"`
+	Ct string `json:"ct"`
+}
+
 type Address struct {
 	Street      string `json:"st"`
 	Housenumber string `json:"hn"`
@@ -308,28 +299,12 @@ type RestaurantData struct {
 		Crn   string  `json:"crn"`
 		Adr   Address `json:"adr"`
 	} `json:"lgl"`
-	Ck string `json:"ck"`
-	Ds int    `json:"ds"`
-	Op string `json:"op"`
-	Ac string `json:"ac"`
-	Dm struct {
-		Ah string `json:"ah"`
-		Dl struct {
-			Op  int    `json:"op"`
-			Mpt string `json:"mpt"`
-			Eta struct {
-				Min int `json:"min"`
-				Max int `json:"max"`
-			} `json:"eta"`
-			Oh string `json:"oh"`
-		} `json:"dl"`
-		Pu struct {
-			Op  int    `json:"op"`
-			Mpt string `json:"mpt"`
-			Oh  string `json:"oh"`
-		} `json:"pu"`
-	} `json:"dm"`
-	Ply int `json:"ply"`
+	Ck  string        `json:"ck"`
+	Ds  int           `json:"ds"`
+	Op  string        `json:"op"`
+	Ac  string        `json:"ac"`
+	Dm  DeliveryModes `json:"dm"`
+	Ply int           `json:"ply"`
 	Pd  struct {
 		Pz struct {
 			Num1 string `json:"1"`
@@ -338,11 +313,7 @@ type RestaurantData struct {
 		} `json:"pz"`
 	} `json:"pd"`
 	Pm struct {
-		Me []struct {
-			Mi string `json:"mi"`
-			Mt string `json:"mt"`
-			Mf string `json:"mf"`
-		} `json:"me"`
+		Me []PaymentMethodData `json:"me"`
 	} `json:"pm"`
 	DeliveryTimes ServiceTimes `json:"dt"`
 	PickupTimes   ServiceTimes `json:"pt"`
@@ -354,12 +325,8 @@ type RestaurantData struct {
 			Postcodes struct {
 				PostCodesArray []string `json:"pp"`
 			} `json:"pc"`
-			Ma    string `json:"ma"`
-			Costs []struct {
-				Fr string `json:"fr"`
-				To string `json:"to"`
-				Ct string `json:"ct"`
-			} `json:"co"`
+			Ma    string         `json:"ma"`
+			Costs []DeliveryCost `json:"co"`
 		} `json:"da"`
 	} `json:"dd"`
 	Menu struct {
@@ -377,9 +344,10 @@ type RestaurantData struct {
 			} `json:"ct"`
 		} `json:"cs"`
 	} `json:"mc"`
+	// Rt is the rating, see RestaurantData.Rating
 	Rt struct {
-		Cr  float64 `json:"cr"`
-		Prr float64 `json:"prr"`
+		Cr  FlexFloat `json:"cr"`
+		Prr FlexFloat `json:"prr"`
 	} `json:"rt"`
 	CurrentTimeStr string `json:"ct"`
 	CurrentTime    time.Time
@@ -387,6 +355,13 @@ type RestaurantData struct {
 	Ce             int    `json:"ce"`
 }
 
+// PaymentMethodData is a payment method as offered by a restaurant, see RestaurantData.PaymentOptions
+type PaymentMethodData struct {
+	Mi string `json:"mi"`
+	Mt string `json:"mt"`
+	Mf string `json:"mf"`
+}
+
 type ServiceTimes struct {
 	CurrentTimeStr string `json:"ct"`
 	CurrentTime    time.Time
@@ -406,26 +381,18 @@ type Times struct {
 type Products []Product
 
 func (p *Products) UnmarshalJSON(data []byte) error {
-	fmt.Printf("Raw JSON for 'pr': %s\n", string(data)) // Debug: Print the raw data
-
 	// Try to unmarshal into a slice (expected case)
 	var products []Product
 	if err := json.Unmarshal(data, &products); err == nil {
 		*p = products
-		fmt.Println("Successfully unmarshaled 'pr' as an array")
 		return nil
-	} else {
-		fmt.Printf("Failed to unmarshal as array: %v\n", err)
 	}
 
 	// If unmarshaling into a slice fails, try unmarshaling a single object
 	var singleProduct Product
 	if err := json.Unmarshal(data, &singleProduct); err == nil {
 		*p = []Product{singleProduct} // Wrap single product into a slice
-		fmt.Println("Successfully unmarshaled 'pr' as a single object")
 		return nil
-	} else {
-		fmt.Printf("Failed to unmarshal as single object: %v\n", err)
 	}
 
 	// If both fail, return an error with the problematic data
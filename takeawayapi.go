@@ -1,11 +1,13 @@
 package takeawayapi
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -34,10 +36,57 @@ type TakeAwayClient struct {
 	Language string
 	HTTP     *http.Client
 	Headers  map[string]string
+	// RateLimiter is waited on before every request sent to the API, nil means no limit
+	RateLimiter RateLimiter
+	// Logger gets a debug record for every request sent to the API, nil disables logging
+	Logger *slog.Logger
+
+	ctx       context.Context
+	auth      *authState
+	flights   *flightGroup
+	cache     *responseCache
+	cacheMode CacheMode
 }
 
-// CallFunction makes a request to the API, processes the response, and unmarshals it into resultStruct
+// sendRequest makes a request to the API, processes the response, and unmarshals it into resultStruct
 func (tac *TakeAwayClient) sendRequest(function string, resultStruct any, params ...interface{}) error {
+	data, md5sum := tac.signRequest(function, params...)
+	token := tac.attachSession(function, data)
+	body, err := tac.fetch(function, md5sum, data)
+	if err != nil {
+		return err
+	}
+
+	// Check if the response contains an error
+	if err := checkAPIError(body); err != nil {
+		if !tac.shouldReauthenticate(function, err) {
+			return err
+		}
+		// Log in again and retry once with the new session
+		if err := tac.reauthenticate(token); err != nil {
+			return fmt.Errorf("error renewing session: %w", err)
+		}
+		data, md5sum = tac.signRequest(function, params...)
+		tac.attachSession(function, data)
+		body, err = tac.fetch(function, md5sum, data)
+		if err != nil {
+			return err
+		}
+		if err := checkAPIError(body); err != nil {
+			return err
+		}
+	}
+
+	// Unmarshal into the provided success struct
+	if err := json.Unmarshal(body, resultStruct); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON: %v", err)
+	}
+	return nil
+}
+
+// signRequest builds the request parameters for function and returns them together with their MD5 checksum.
+// The language parameter is the language of the client.
+func (tac *TakeAwayClient) signRequest(function string, params ...interface{}) (url.Values, string) {
 	// Generate MD5 checksum
 	hash := md5.New()
 	paramStrings := []string{function}
@@ -64,11 +113,24 @@ func (tac *TakeAwayClient) sendRequest(function string, resultStruct any, params
 	for key, value := range defaultParams {
 		data.Set(key, value)
 	}
+	if tac.Language != "" {
+		data.Set("language", tac.Language)
+	}
+	return data, md5sum
+}
+
+// doRequest posts the request parameters to the API and returns the raw response body
+func (tac *TakeAwayClient) doRequest(ctx context.Context, data url.Values) ([]byte, error) {
+	if tac.RateLimiter != nil {
+		if err := tac.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create request
-	req, err := http.NewRequest("POST", tac.BaseURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", tac.BaseURL, strings.NewReader(data.Encode()))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
@@ -79,28 +141,42 @@ func (tac *TakeAwayClient) sendRequest(function string, resultStruct any, params
 	}
 
 	// Make request
-	client := &http.Client{}
+	client := tac.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	start := time.Now()
 	resp, err := client.Do(req)
-	if err != nil {
-		return err
+	var body []byte
+	if err == nil {
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
 	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
+	if tac.Logger != nil {
+		tac.Logger.LogAttrs(ctx, slog.LevelDebug, "takeaway api request",
+			slog.String("function", data.Get("var1")),
+			slog.String("url", tac.BaseURL),
+			slog.Duration("duration", time.Since(start)),
+			slog.Any("error", err))
 	}
+	return body, err
+}
 
-	// Check if the response contains an error
+// APIError is an error reported by the API
+type APIError struct {
+	ID   int
+	Text string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %d %s", e.ID, e.Text)
+}
+
+// checkAPIError returns an *APIError if body contains an API error
+func checkAPIError(body []byte) error {
 	var apiError apiError
 	if err := json.Unmarshal(body, &apiError); err == nil && apiError.Nok.Error.ErrorID != 0 {
-		return fmt.Errorf("API error: %v", apiError.Nok.Error)
-	}
-
-	// Unmarshal into the provided success struct
-	if err := json.Unmarshal(body, resultStruct); err != nil {
-		return fmt.Errorf("failed to unmarshal JSON: %v", err)
+		return &APIError{ID: apiError.Nok.Error.ErrorID, Text: apiError.Nok.Error.ErrorText}
 	}
 	return nil
 }
@@ -113,6 +189,7 @@ func NewClient(language string) *TakeAwayClient {
 		Language: language,
 		HTTP:     &http.Client{Timeout: time.Second * 10},
 		Headers:  map[string]string{},
+		flights:  newFlightGroup(),
 	}
 }
 
@@ -124,7 +201,26 @@ func NewClientWithHTTPClient(language string, httpClient *http.Client) *TakeAway
 		Language: language,
 		HTTP:     httpClient,
 		Headers:  map[string]string{},
+		flights:  newFlightGroup(),
+	}
+}
+
+// WithContext returns a copy of the client which uses ctx for all its requests.
+// The copy shares headers, cache and in-flight requests with the original client.
+//
+//	data, err := tac.WithContext(ctx).GetRestaurantData(...)
+func (tac *TakeAwayClient) WithContext(ctx context.Context) *TakeAwayClient {
+	client := *tac
+	client.ctx = ctx
+	return &client
+}
+
+// context returns the context of the client, defaulting to context.Background
+func (tac *TakeAwayClient) context() context.Context {
+	if tac.ctx == nil {
+		return context.Background()
 	}
+	return tac.ctx
 }
 
 // SetHeader sets a header for the client
@@ -150,11 +246,11 @@ func (tac *TakeAwayClient) GetCurrentTime(cc CountryCode, RestaurantID string, O
 	var currentTimeResponse currentTimeResponseOuter
 	err := tac.sendRequest(function, &currentTimeResponse, cc, RestaurantID, OrderingMode)
 	if err != nil {
-		return CurrentTimeResponse{}, fmt.Errorf("error sending %s request: %v", function, err)
+		return CurrentTimeResponse{}, fmt.Errorf("error sending %s request: %w", function, err)
 	}
 	currentTimeResponse.CurrentTimeResponse.CurrentTime, err = ParseTakeAwayTime(currentTimeResponse.CurrentTimeResponse.CurrentTimeStr)
 	if err != nil {
-		return CurrentTimeResponse{}, fmt.Errorf("error parsing current time: %v", err)
+		return CurrentTimeResponse{}, fmt.Errorf("error parsing current time: %w", err)
 	}
 	return currentTimeResponse.CurrentTimeResponse, nil
 }
@@ -165,11 +261,11 @@ func (tac *TakeAwayClient) GetRestaurants(postalCode string, cc CountryCode, lat
 	var restaurantsResponse restaurantsResponseOuter
 	err := tac.sendRequest(function, &restaurantsResponse, postalCode, cc, latitude, longitude, tac.Language)
 	if err != nil {
-		return RestaurantsResponse{}, fmt.Errorf("error sending %s request: %v", function, err)
+		return RestaurantsResponse{}, fmt.Errorf("error sending %s request: %w", function, err)
 	}
 	restaurantsResponse.RestaurantsResponse.CurrentTime, err = ParseTakeAwayTime(restaurantsResponse.RestaurantsResponse.CurrentTimeStr)
 	if err != nil {
-		return RestaurantsResponse{}, fmt.Errorf("error parsing current time: %v", err)
+		return RestaurantsResponse{}, fmt.Errorf("error parsing current time: %w", err)
 	}
 	return restaurantsResponse.RestaurantsResponse, nil
 }
@@ -180,7 +276,7 @@ func (tac *TakeAwayClient) GetCountriesData() (AvailableCountries, error) {
 	var countriesResponse countriesResponse
 	err := tac.sendRequest(function, &countriesResponse)
 	if err != nil {
-		return AvailableCountries{}, fmt.Errorf("error sending %s request: %v", function, err)
+		return AvailableCountries{}, fmt.Errorf("error sending %s request: %w", function, err)
 	}
 	return countriesResponse.AvailableCountries, nil
 }
@@ -189,30 +285,30 @@ func (tac *TakeAwayClient) getRestaurantData(function string, restaurantId strin
 	var restaurantDataResponse restaurantDataResponse
 	err := tac.sendRequest(function, &restaurantDataResponse, restaurantId, cc, postcode, latitude, longitude, clientID)
 	if err != nil {
-		return RestaurantData{}, fmt.Errorf("error sending %s request: %v", function, err)
+		return RestaurantData{}, fmt.Errorf("error sending %s request: %w", function, err)
 	}
 	restaurantDataResponse.RestaurantData.CurrentTime, err = ParseTakeAwayTime(restaurantDataResponse.RestaurantData.CurrentTimeStr)
 	if err != nil {
-		return RestaurantData{}, fmt.Errorf("error parsing current time: %v", err)
+		return RestaurantData{}, fmt.Errorf("error parsing current time: %w", err)
 	}
 	for i := range restaurantDataResponse.RestaurantData.DeliveryTimes.Td.Times {
 		restaurantDataResponse.RestaurantData.DeliveryTimes.Td.Times[i].StartTime, err = ParseTakeAwayTime(restaurantDataResponse.RestaurantData.DeliveryTimes.Td.Times[i].StartTimeStr)
 		if err != nil {
-			return RestaurantData{}, fmt.Errorf("error parsing delivery StartTimeStr time: %v", err)
+			return RestaurantData{}, fmt.Errorf("error parsing delivery StartTimeStr time: %w", err)
 		}
 		restaurantDataResponse.RestaurantData.DeliveryTimes.Td.Times[i].EndTime, err = ParseTakeAwayTime(restaurantDataResponse.RestaurantData.DeliveryTimes.Td.Times[i].EndTimeStr)
 		if err != nil {
-			return RestaurantData{}, fmt.Errorf("error parsing delivery EndTime time: %v", err)
+			return RestaurantData{}, fmt.Errorf("error parsing delivery EndTime time: %w", err)
 		}
 	}
 	for i := range restaurantDataResponse.RestaurantData.DeliveryTimes.Tm.Times {
 		restaurantDataResponse.RestaurantData.DeliveryTimes.Tm.Times[i].StartTime, err = ParseTakeAwayTime(restaurantDataResponse.RestaurantData.DeliveryTimes.Tm.Times[i].StartTimeStr)
 		if err != nil {
-			return RestaurantData{}, fmt.Errorf("error parsing delivery StartTimeStr time: %v", err)
+			return RestaurantData{}, fmt.Errorf("error parsing delivery StartTimeStr time: %w", err)
 		}
 		restaurantDataResponse.RestaurantData.DeliveryTimes.Tm.Times[i].EndTime, err = ParseTakeAwayTime(restaurantDataResponse.RestaurantData.DeliveryTimes.Tm.Times[i].EndTimeStr)
 		if err != nil {
-			return RestaurantData{}, fmt.Errorf("error parsing delivery EndTime time: %v", err)
+			return RestaurantData{}, fmt.Errorf("error parsing delivery EndTime time: %w", err)
 		}
 	}
 	return restaurantDataResponse.RestaurantData, nil
@@ -236,7 +332,7 @@ func (tac *TakeAwayClient) GetRestaurantReviews(restaurantID string, page int) (
 	var restaurantReviewsResponse reviewsResponse
 	err := tac.sendRequest(function, &restaurantReviewsResponse, restaurantID, page)
 	if err != nil {
-		return []Review{}, fmt.Errorf("error sending %s request: %v", function, err)
+		return []Review{}, fmt.Errorf("error sending %s request: %w", function, err)
 	}
 	return restaurantReviewsResponse.ReviewStruct.Reviews, nil
 }
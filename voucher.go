@@ -0,0 +1,89 @@
+package takeawayapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// VoucherType is the kind of discount a voucher gives
+type VoucherType int
+
+const (
+	// VoucherAmount takes a fixed amount off the subtotal
+	VoucherAmount VoucherType = 1
+	// VoucherPercentage takes a percentage off the subtotal
+	VoucherPercentage VoucherType = 2
+)
+
+type voucherResponse struct {
+	Voucher Voucher `json:"vc"`
+}
+
+// Voucher is the result of validating a voucher code
+type Voucher struct {
+	Code  string      `json:"cd"`
+	Valid bool        `json:"vl"`
+	Type  VoucherType `json:"tp"`
+	// Amount is the discount of a VoucherAmount
+	Amount Money `json:"am"`
+	// Percentage is the discount of a VoucherPercentage, e.g. 10 for 10%
+	Percentage   float64 `json:"pc"`
+	MinimumOrder Money   `json:"mo"`
+	ExpiresAtStr string  `json:"ex"`
+	ExpiresAt    time.Time
+	// RestaurantIDs limits the voucher to these restaurants, empty means all restaurants
+	RestaurantIDs []string `json:"ri"`
+}
+
+// ValidateVoucher checks a voucher code for the given restaurant
+func (tac *TakeAwayClient) ValidateVoucher(code string, restaurantID string) (Voucher, error) {
+	function := "validatevoucher"
+	var voucherResponse voucherResponse
+	err := tac.sendRequest(function, &voucherResponse, code, restaurantID)
+	if err != nil {
+		return Voucher{}, fmt.Errorf("error sending %s request: %w", function, err)
+	}
+	voucher := voucherResponse.Voucher
+	if voucher.ExpiresAtStr != "" {
+		voucher.ExpiresAt, err = ParseTakeAwayTime(voucher.ExpiresAtStr)
+		if err != nil {
+			return Voucher{}, fmt.Errorf("error parsing voucher expiry time: %w", err)
+		}
+	}
+	return voucher, nil
+}
+
+// AppliesTo reports whether the voucher can be used at the restaurant
+func (v Voucher) AppliesTo(restaurantID string) bool {
+	return len(v.RestaurantIDs) == 0 || slices.Contains(v.RestaurantIDs, restaurantID)
+}
+
+// Discount returns the amount the voucher takes off subtotal at the restaurant.
+// The discount never exceeds the subtotal.
+func (v Voucher) Discount(restaurantID string, subtotal Money) (Money, error) {
+	if !v.Valid {
+		return 0, fmt.Errorf("voucher %s is not valid", v.Code)
+	}
+	if !v.ExpiresAt.IsZero() && time.Now().After(v.ExpiresAt) {
+		return 0, fmt.Errorf("voucher %s expired at %v", v.Code, v.ExpiresAt)
+	}
+	if !v.AppliesTo(restaurantID) {
+		return 0, fmt.Errorf("voucher %s is not valid for restaurant %s", v.Code, restaurantID)
+	}
+	if subtotal < v.MinimumOrder {
+		return 0, fmt.Errorf("voucher %s requires a minimum order of %v", v.Code, v.MinimumOrder)
+	}
+	var discount Money
+	switch v.Type {
+	case VoucherAmount:
+		discount = v.Amount
+	case VoucherPercentage:
+		discount = Money(math.Round(float64(subtotal) * v.Percentage / 100))
+	default:
+		return 0, errors.New("unknown voucher type")
+	}
+	return min(discount, subtotal), nil
+}
@@ -0,0 +1,56 @@
+package takeawayapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateVoucher(t *testing.T) {
+	tac, ts := newTestClient(t, map[string]string{
+		"validatevoucher": `{"vc":{"cd":"LUNCH10","vl":true,"tp":2,"pc":10,"mo":"15.00","ex":"2099-12-31 23:59:59","ri":["O3QQ11PN"]}}`,
+	})
+	voucher, err := tac.ValidateVoucher("LUNCH10", "O3QQ11PN")
+	if err != nil {
+		t.Fatalf(`ValidateVoucher errored with error: %v`, err)
+	}
+	if !voucher.Valid || voucher.Type != VoucherPercentage || voucher.MinimumOrder != 1500 || voucher.ExpiresAt.IsZero() {
+		t.Fatalf(`ValidateVoucher decoded wrong voucher: %+v`, voucher)
+	}
+	if form := ts.LastForm("validatevoucher"); form.Get("var2") != "LUNCH10" || form.Get("var3") != "O3QQ11PN" {
+		t.Fatalf(`ValidateVoucher sent wrong parameters: %v`, form)
+	}
+
+	basket := Basket{RestaurantID: "O3QQ11PN", Mode: OrderModePickup, Voucher: &voucher}
+	basket.Add("p3", 2)
+	validated, err := basket.Validate(testRestaurantData(t))
+	if err != nil {
+		t.Fatalf(`Validate errored with error: %v`, err)
+	}
+	if validated.Totals.Subtotal != 2200 || validated.Totals.Discount != 220 || validated.Totals.Total != 1980 {
+		t.Fatalf(`Voucher was applied wrong: %+v`, validated.Totals)
+	}
+
+	basket.Items = []BasketItem{{ProductID: "p3", Quantity: 1}}
+	if _, err := basket.Validate(testRestaurantData(t)); err == nil {
+		t.Fatalf(`Voucher was applied below its minimum order`)
+	}
+}
+
+func TestVoucherDiscount(t *testing.T) {
+	voucher := Voucher{Code: "FIVE", Valid: true, Type: VoucherAmount, Amount: 500}
+	if discount, err := voucher.Discount("any", 300); err != nil || discount != 300 {
+		t.Fatalf(`Discount must not exceed the subtotal: %v, %v`, discount, err)
+	}
+	voucher.ExpiresAt = time.Now().Add(-time.Hour)
+	if _, err := voucher.Discount("any", 1000); err == nil {
+		t.Fatalf(`Expired voucher was accepted`)
+	}
+	voucher = Voucher{Code: "ONE", Valid: true, Type: VoucherAmount, Amount: 500, RestaurantIDs: []string{"A"}}
+	if _, err := voucher.Discount("B", 1000); err == nil {
+		t.Fatalf(`Voucher was accepted for another restaurant`)
+	}
+	voucher.Valid = false
+	if _, err := voucher.Discount("A", 1000); err == nil {
+		t.Fatalf(`Invalid voucher was accepted`)
+	}
+}